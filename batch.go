@@ -5,77 +5,69 @@ import (
 )
 
 // Batch allows looping over an [iter.Seq] sequence in batches of a
-// given size. The batch size must be positive.
+// given size. The batch size must be positive. Values are pulled from
+// the sequence lazily and at most one batch of values is buffered at
+// a time, so the sequence may be infinite. Each batch is only valid
+// until the next batch is requested.
 func Batch[V any](seq iter.Seq[V], size int) iter.Seq[iter.Seq[V]] {
 	if size <= 0 {
 		panic("size must be positive")
 	}
 
-	l := ToList(seq)
-
 	return func(yield func(iter.Seq[V]) bool) {
-		elem := l.Front()
+		next, stop := iter.Pull(seq)
+		defer stop()
 
-		for {
-			if elem == nil {
-				return
-			}
+		buffer := newRing[V](size)
+		exhausted := false
 
-			if !yield(func(yield func(V) bool) {
-				for range size {
-					if elem == nil {
-						return
-					}
+		pull := func() bool {
+			if exhausted || buffer.Full() {
+				return false
+			}
 
-					if !yield(elem.Value.(V)) {
-						return
-					}
+			value, ok := next()
+			if !ok {
+				exhausted = true
 
-					elem = elem.Next()
-				}
-			}) {
-				return
+				return false
 			}
-		}
-	}
-}
-
-// Batch2 allows looping over an [iter.Seq2] sequence in batches of a
-// given size. The batch size must be positive.
-func Batch2[K, V any](seq iter.Seq2[K, V], size int) iter.Seq[iter.Seq2[K, V]] {
-	if size <= 0 {
-		panic("size must be positive")
-	}
 
-	listKeys, listValues := ToList2(seq)
+			buffer.Push(value)
 
-	return func(yield func(iter.Seq2[K, V]) bool) {
-		keyElem := listKeys.Front()
-		valueElem := listValues.Front()
+			return true
+		}
 
 		for {
-			if keyElem == nil || valueElem == nil {
+			buffer.Reset()
+
+			if !pull() {
 				return
 			}
 
-			if !yield(func(yield func(K, V) bool) {
-				for range size {
-					if keyElem == nil || valueElem == nil {
+			batch := func(yield func(V) bool) {
+				for i := 0; i < buffer.Len() || pull(); i++ {
+					if !yield(buffer.At(i)) {
 						return
 					}
-
-					key := keyElem.Value.(K)
-					value := valueElem.Value.(V)
-					if !yield(key, value) {
-						return
-					}
-
-					keyElem = keyElem.Next()
-					valueElem = valueElem.Next()
 				}
-			}) {
+			}
+
+			if !yield(batch) {
 				return
 			}
+
+			for pull() {
+			}
 		}
 	}
 }
+
+// Batch2 allows looping over an [iter.Seq2] sequence in batches of a
+// given size. The batch size must be positive. Values are pulled from
+// the sequence lazily and at most one batch of values is buffered at
+// a time, so the sequence may be infinite. Each batch is only valid
+// until the next batch is requested.
+func Batch2[K, V any](seq iter.Seq2[K, V], size int) iter.Seq[iter.Seq2[K, V]] {
+	return Transform(Batch(KeyValue2(seq), size), KeyValue[K, V])
+}
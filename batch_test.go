@@ -1,6 +1,7 @@
 package gloop_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/alvii147/gloop"
@@ -101,6 +102,15 @@ func TestBatchBreak(t *testing.T) {
 	}
 }
 
+func TestBatchSizeLargerThanSequence(t *testing.T) {
+	values := []int{3, 1, 4}
+
+	for _, size := range []int{1e8, math.MaxInt} {
+		batches := gloop.ToSlice(gloop.Transform(gloop.Batch(gloop.Slice(values), size), gloop.ToSlice[int]))
+		require.Equal(t, [][]int{{3, 1, 4}}, batches)
+	}
+}
+
 func TestBatchZeroSizePanics(t *testing.T) {
 	require.Panics(t, func() {
 		for range gloop.Batch(gloop.Slice([]int{3, 1, 4}), 0) {
@@ -202,3 +212,148 @@ func TestBatch2NegativeSizePanics(t *testing.T) {
 		}
 	})
 }
+
+func TestBatchInfiniteSequence(t *testing.T) {
+	naturals := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	wantBatches := [][]int{
+		{0, 1, 2},
+		{3, 4, 5},
+		{6, 7, 8},
+	}
+	i := 0
+
+	for seq := range gloop.Batch(naturals, 3) {
+		if i == len(wantBatches) {
+			break
+		}
+
+		batch := gloop.ToSlice(seq)
+		require.Equal(t, wantBatches[i], batch)
+
+		i++
+	}
+
+	require.Equal(t, len(wantBatches), i)
+}
+
+func TestBatchPartiallyConsumed(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5, 9, 2, 6, 5}
+	wantFirstValues := []int{3, 1, 2}
+	i := 0
+
+	for seq := range gloop.Batch(gloop.Slice(values), 3) {
+		if i%2 == 0 {
+			for value := range seq {
+				require.Equal(t, wantFirstValues[i], value)
+
+				break
+			}
+		}
+
+		i++
+	}
+
+	require.Equal(t, len(wantFirstValues), i)
+}
+
+func TestBatchRepeatedlyConsumed(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5}
+	wantBatches := [][]int{
+		{3, 1, 4},
+		{1, 5},
+	}
+	i := 0
+
+	for seq := range gloop.Batch(gloop.Slice(values), 3) {
+		require.Equal(t, wantBatches[i], gloop.ToSlice(seq))
+		require.Equal(t, wantBatches[i], gloop.ToSlice(seq))
+
+		i++
+	}
+
+	require.Equal(t, len(wantBatches), i)
+}
+
+func TestBatchLazy(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5, 9, 2, 6, 5}
+	pulled := 0
+	seq := func(yield func(int) bool) {
+		for _, value := range values {
+			pulled++
+
+			if !yield(value) {
+				return
+			}
+		}
+	}
+
+	for batch := range gloop.Batch(seq, 3) {
+		require.Equal(t, 1, pulled)
+
+		for range batch {
+			break
+		}
+
+		require.Equal(t, 1, pulled)
+
+		break
+	}
+
+	require.Equal(t, 1, pulled)
+}
+
+func TestBatchChannel(t *testing.T) {
+	ch := make(chan int)
+
+	go func() {
+		for i := range 7 {
+			ch <- i
+		}
+
+		close(ch)
+	}()
+
+	wantBatches := [][]int{
+		{0, 1, 2},
+		{3, 4, 5},
+		{6},
+	}
+	i := 0
+
+	for seq := range gloop.Batch(gloop.Channel(ch), 3) {
+		batch := gloop.ToSlice(seq)
+		require.Equal(t, wantBatches[i], batch)
+
+		i++
+	}
+
+	require.Equal(t, len(wantBatches), i)
+}
+
+func TestBatch2PartiallyConsumed(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5, 9, 2, 6, 5}
+	wantFirstKeys := []int{0, 3, 6}
+	wantFirstValues := []int{3, 1, 2}
+	i := 0
+
+	for seq := range gloop.Batch2(gloop.Enumerate(gloop.Slice(values)), 3) {
+		if i%2 == 0 {
+			for key, value := range seq {
+				require.Equal(t, wantFirstKeys[i], key)
+				require.Equal(t, wantFirstValues[i], value)
+
+				break
+			}
+		}
+
+		i++
+	}
+
+	require.Equal(t, len(wantFirstValues), i)
+}
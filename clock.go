@@ -0,0 +1,42 @@
+package gloop
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is a source of time used by time-dependent iterators. It can
+// be replaced with a fake implementation so that tests do not need to
+// wait for real time to pass.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After waits for a given duration to elapse and then sends the
+	// current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is a [Clock] that uses the system time.
+type SystemClock struct{}
+
+// Now returns the current system time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After waits for a given duration to elapse and then sends the
+// current system time on the returned channel.
+func (SystemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// sleep waits for a given duration to elapse on a given clock. It
+// returns false if the context is cancelled first.
+func sleep(ctx context.Context, clock Clock, d time.Duration) bool {
+	select {
+	case <-clock.After(d):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
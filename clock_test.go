@@ -0,0 +1,115 @@
+package gloop_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a [gloop.Clock] whose time only moves when advanced.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeClockWaiter
+	calls   chan struct{}
+}
+
+type fakeClockWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{
+		now:   now,
+		calls: make(chan struct{}, 1024),
+	}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+	} else {
+		c.waiters = append(c.waiters, fakeClockWaiter{
+			deadline: c.now.Add(d),
+			ch:       ch,
+		})
+	}
+
+	c.calls <- struct{}{}
+
+	return ch
+}
+
+// Advance moves the clock forward and fires all waiters whose
+// deadlines have passed.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	waiters := c.waiters[:0]
+
+	for _, waiter := range c.waiters {
+		if waiter.deadline.After(c.now) {
+			waiters = append(waiters, waiter)
+
+			continue
+		}
+
+		waiter.ch <- c.now
+	}
+
+	c.waiters = waiters
+}
+
+// Skip moves the clock forward without firing any waiters.
+func (c *fakeClock) Skip(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+// WaitForAfter blocks until After has been called once more since the
+// last call to WaitForAfter.
+func (c *fakeClock) WaitForAfter(t *testing.T) {
+	t.Helper()
+
+	select {
+	case <-c.calls:
+	case <-time.After(time.Second * 10):
+		t.Fatal("After call took too long")
+	}
+}
+
+func TestSystemClockNow(t *testing.T) {
+	before := time.Now()
+	now := gloop.SystemClock{}.Now()
+	after := time.Now()
+
+	require.False(t, now.Before(before))
+	require.False(t, now.After(after))
+}
+
+func TestSystemClockAfter(t *testing.T) {
+	select {
+	case <-gloop.SystemClock{}.After(time.Millisecond):
+	case <-time.After(time.Second * 10):
+		t.Fatal("After took too long")
+	}
+}
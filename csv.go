@@ -0,0 +1,495 @@
+package gloop
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"strconv"
+)
+
+// CSVOptions defines configurable options for [CSVRecords], [CSV],
+// [ToCSVRecords], [ToCSV] and [ToCSV2].
+type CSVOptions struct {
+	// Comma is the field delimiter.
+	Comma rune
+	// Comment is the character that starts a comment line when
+	// reading. If zero, comment lines are not recognized. This is not
+	// used when writing.
+	Comment rune
+}
+
+// CSVOptionFunc is the function signature of configuration helpers
+// for [CSVRecords], [CSV], [ToCSVRecords], [ToCSV] and [ToCSV2].
+type CSVOptionFunc func(*CSVOptions)
+
+// WithCSVComma is a helper for configuring the field delimiter in
+// [CSVRecords], [CSV], [ToCSVRecords], [ToCSV] and [ToCSV2].
+func WithCSVComma(comma rune) CSVOptionFunc {
+	return func(o *CSVOptions) {
+		o.Comma = comma
+	}
+}
+
+// WithCSVComment is a helper for configuring the comment character in
+// [CSVRecords] and [CSV].
+func WithCSVComment(comment rune) CSVOptionFunc {
+	return func(o *CSVOptions) {
+		o.Comment = comment
+	}
+}
+
+// newCSVOptions returns the default [CSVOptions] with the given
+// configuration helpers applied.
+func newCSVOptions(opts ...CSVOptionFunc) CSVOptions {
+	options := CSVOptions{
+		Comma:   ',',
+		Comment: 0,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// newCSVReader creates a [encoding/csv.Reader] configured by the given
+// options.
+func newCSVReader(r io.Reader, options CSVOptions) *csv.Reader {
+	reader := csv.NewReader(r)
+	reader.Comma = options.Comma
+	reader.Comment = options.Comment
+	reader.FieldsPerRecord = -1
+
+	return reader
+}
+
+// CSVError is the error yielded by [CSV] when a field in a record
+// cannot be decoded.
+type CSVError struct {
+	// Line is the line number of the record, starting at 1.
+	Line int
+	// Column is the header name of the field.
+	Column string
+	// Err is the underlying decoding error.
+	Err error
+}
+
+// Error returns the error message.
+func (e *CSVError) Error() string {
+	return fmt.Sprintf("line %d, column %q: %v", e.Line, e.Column, e.Err)
+}
+
+// Unwrap returns the underlying decoding error.
+func (e *CSVError) Unwrap() error {
+	return e.Err
+}
+
+// CSVRecords allows looping over records read from an [io.Reader] of
+// comma-separated values. If a record cannot be parsed, a
+// [encoding/csv.ParseError] with its line number is yielded and
+// looping continues. If reading fails, the error is yielded and
+// looping ends.
+func CSVRecords(r io.Reader, opts ...CSVOptionFunc) iter.Seq2[[]string, error] {
+	options := newCSVOptions(opts...)
+
+	return func(yield func([]string, error) bool) {
+		reader := newCSVReader(r, options)
+
+		for {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			var parseErr *csv.ParseError
+			if err != nil && !errors.As(err, &parseErr) {
+				yield(nil, err)
+
+				return
+			}
+
+			if !yield(record, err) {
+				return
+			}
+		}
+	}
+}
+
+// CSV allows looping over records read from an [io.Reader] of
+// comma-separated values, decoded into values of a given struct type.
+// The first record is the header, and each field is decoded into the
+// struct field whose "csv" tag or name matches the header name. Fields
+// tagged with "-" are ignored. Struct fields must be strings, booleans,
+// integers, floating point numbers or implement
+// [encoding.TextUnmarshaler]. If a record cannot be parsed or decoded,
+// the error is yielded along with a zero value and looping continues.
+// Decoding errors are of type [*CSVError]. Nil embedded struct pointers
+// are allocated when decoding fields promoted through them, which fails
+// for pointers to unexported struct types. If reading fails, the error
+// is yielded and looping ends.
+func CSV[T any](r io.Reader, opts ...CSVOptionFunc) iter.Seq2[T, error] {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		panic("type must be a struct")
+	}
+
+	options := newCSVOptions(opts...)
+	columns := csvColumns(typ)
+
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		reader := newCSVReader(r, options)
+
+		header, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+
+		if err != nil {
+			yield(zero, err)
+
+			return
+		}
+
+		fields := make([][]int, len(header))
+		for i, name := range header {
+			fields[i] = columns[name]
+		}
+
+		for {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			var parseErr *csv.ParseError
+			if err != nil && !errors.As(err, &parseErr) {
+				yield(zero, err)
+
+				return
+			}
+
+			if err != nil {
+				if !yield(zero, err) {
+					return
+				}
+
+				continue
+			}
+
+			var value T
+			err = nil
+			v := reflect.ValueOf(&value).Elem()
+
+			for i, field := range record {
+				if i >= len(fields) || fields[i] == nil {
+					continue
+				}
+
+				decodeErr := csvDecodeField(v, fields[i], field)
+				if decodeErr != nil {
+					line, _ := reader.FieldPos(i)
+					err = &CSVError{
+						Line:   line,
+						Column: header[i],
+						Err:    decodeErr,
+					}
+
+					break
+				}
+			}
+
+			if err != nil {
+				value = zero
+			}
+
+			if !yield(value, err) {
+				return
+			}
+		}
+	}
+}
+
+// ToCSVRecords writes records from an [iter.Seq] sequence to an
+// [io.Writer] as comma-separated values.
+func ToCSVRecords(w io.Writer, seq iter.Seq[[]string], opts ...CSVOptionFunc) error {
+	options := newCSVOptions(opts...)
+	writer := csv.NewWriter(w)
+	writer.Comma = options.Comma
+
+	for record := range seq {
+		err := writer.Write(record)
+		if err != nil {
+			return fmt.Errorf("failed to write CSV record: %w", err)
+		}
+	}
+
+	writer.Flush()
+
+	err := writer.Error()
+	if err != nil {
+		return fmt.Errorf("failed to flush CSV writer: %w", err)
+	}
+
+	return nil
+}
+
+// ToCSV writes values of a given struct type from an [iter.Seq]
+// sequence to an [io.Writer] as comma-separated values. A header is
+// written first, with each struct field named by its "csv" tag or
+// name. Fields tagged with "-" are ignored. Struct fields must be
+// strings, booleans, integers, floating point numbers or implement
+// [encoding.TextMarshaler]. Fields promoted through nil embedded struct
+// pointers are written as empty fields.
+func ToCSV[T any](w io.Writer, seq iter.Seq[T], opts ...CSVOptionFunc) error {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		panic("type must be a struct")
+	}
+
+	names, fields := csvHeader(typ)
+
+	var encodeErr error
+
+	records := func(yield func([]string) bool) {
+		if !yield(names) {
+			return
+		}
+
+		for value := range seq {
+			v := reflect.ValueOf(value)
+			record := make([]string, len(fields))
+
+			for i, field := range fields {
+				fieldValue, err := v.FieldByIndexErr(field)
+				if err != nil {
+					// the field is promoted through a nil embedded
+					// pointer.
+					continue
+				}
+
+				record[i], encodeErr = csvEncode(fieldValue)
+				if encodeErr != nil {
+					return
+				}
+			}
+
+			if !yield(record) {
+				return
+			}
+		}
+	}
+
+	err := ToCSVRecords(w, records, opts...)
+	if encodeErr != nil {
+		return fmt.Errorf("failed to encode CSV field: %w", encodeErr)
+	}
+
+	return err
+}
+
+// ToCSV2 writes keys and values from an [iter.Seq2] sequence to an
+// [io.Writer] as comma-separated values, with one record of two fields
+// for each key and value. Keys and values must be strings, booleans,
+// integers, floating point numbers or implement
+// [encoding.TextMarshaler].
+func ToCSV2[K, V any](w io.Writer, seq iter.Seq2[K, V], opts ...CSVOptionFunc) error {
+	var encodeErr error
+
+	records := func(yield func([]string) bool) {
+		for key, value := range seq {
+			record := make([]string, 2)
+
+			record[0], encodeErr = csvEncode(reflect.ValueOf(&key).Elem())
+			if encodeErr != nil {
+				return
+			}
+
+			record[1], encodeErr = csvEncode(reflect.ValueOf(&value).Elem())
+			if encodeErr != nil {
+				return
+			}
+
+			if !yield(record) {
+				return
+			}
+		}
+	}
+
+	err := ToCSVRecords(w, records, opts...)
+	if encodeErr != nil {
+		return fmt.Errorf("failed to encode CSV field: %w", encodeErr)
+	}
+
+	return err
+}
+
+// errCSVUnsupportedType is returned when a value cannot be encoded to
+// or decoded from a comma-separated field.
+var errCSVUnsupportedType = errors.New("unsupported type")
+
+// csvName returns the column name of a struct field, and false if the
+// field is ignored.
+func csvName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+
+	name, ok := field.Tag.Lookup("csv")
+	if !ok || name == "" {
+		return field.Name, true
+	}
+
+	if name == "-" {
+		return "", false
+	}
+
+	return name, true
+}
+
+// csvHeader returns the column names and field indices of a struct
+// type in field order.
+func csvHeader(typ reflect.Type) ([]string, [][]int) {
+	names := make([]string, 0, typ.NumField())
+	fields := make([][]int, 0, typ.NumField())
+
+	for _, field := range reflect.VisibleFields(typ) {
+		if field.Anonymous {
+			continue
+		}
+
+		name, ok := csvName(field)
+		if !ok {
+			continue
+		}
+
+		names = append(names, name)
+		fields = append(fields, field.Index)
+	}
+
+	return names, fields
+}
+
+// csvColumns returns the field indices of a struct type by column
+// name.
+func csvColumns(typ reflect.Type) map[string][]int {
+	names, fields := csvHeader(typ)
+	columns := make(map[string][]int, len(names))
+
+	for i, name := range names {
+		columns[name] = fields[i]
+	}
+
+	return columns
+}
+
+// errCSVUnexportedEmbeddedPointer is returned when a field promoted
+// through a nil pointer to an unexported struct type cannot be decoded.
+var errCSVUnexportedEmbeddedPointer = errors.New("cannot set embedded pointer to unexported struct")
+
+// csvDecodeField decodes a comma-separated field into the nested struct
+// field of a value with a given index, allocating nil embedded struct
+// pointers on the way.
+func csvDecodeField(v reflect.Value, index []int, field string) error {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return errCSVUnexportedEmbeddedPointer
+				}
+
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(x)
+	}
+
+	return csvDecode(v, field)
+}
+
+// csvEncode encodes a value to a comma-separated field.
+func csvEncode(v reflect.Value) (string, error) {
+	if marshaler, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal text: %w", err)
+		}
+
+		return string(text), nil
+	}
+
+	//exhaustive:ignore
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	default:
+		return "", fmt.Errorf("failed to encode %v: %w", v.Type(), errCSVUnsupportedType)
+	}
+}
+
+// csvDecode decodes a comma-separated field into a settable value.
+func csvDecode(v reflect.Value, field string) error {
+	if unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		err := unmarshaler.UnmarshalText([]byte(field))
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal text: %w", err)
+		}
+
+		return nil
+	}
+
+	//exhaustive:ignore
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(field)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(field)
+		if err != nil {
+			return fmt.Errorf("failed to parse bool: %w", err)
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(field, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("failed to parse int: %w", err)
+		}
+
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(field, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("failed to parse uint: %w", err)
+		}
+
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(field, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("failed to parse float: %w", err)
+		}
+
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("failed to decode %v: %w", v.Type(), errCSVUnsupportedType)
+	}
+
+	return nil
+}
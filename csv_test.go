@@ -0,0 +1,565 @@
+package gloop_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+type csvAnimal struct {
+	Name     string  `csv:"name"`
+	Legs     int     `csv:"legs"`
+	Weight   float64 `csv:"weight"`
+	Domestic bool
+	Ignored  string `csv:"-"`
+	ignored  string
+}
+
+type csvEmbedded struct {
+	csvAnimal
+	Color string `csv:"color"`
+}
+
+type CSVShade struct {
+	Shade string `csv:"shade"`
+}
+
+type csvEmbeddedPointer struct {
+	*CSVShade
+	*csvAnimal
+	Color string `csv:"color"`
+}
+
+type csvTypes struct {
+	Int8    int8
+	Uint16  uint16
+	Float32 float32
+	Time    time.Time
+}
+
+type csvUnsupported struct {
+	Values []int
+}
+
+type csvTextError struct{}
+
+var errCSVTextTest = errors.New("csv text test error")
+
+func (csvTextError) MarshalText() ([]byte, error) {
+	return nil, errCSVTextTest
+}
+
+func (*csvTextError) UnmarshalText([]byte) error {
+	return errCSVTextTest
+}
+
+type failingWriter struct{}
+
+var errWriterTest = errors.New("writer test error")
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errWriterTest
+}
+
+func TestWithCSVComma(t *testing.T) {
+	options := gloop.CSVOptions{}
+	gloop.WithCSVComma(';')(&options)
+	require.Equal(t, ';', options.Comma)
+}
+
+func TestWithCSVComment(t *testing.T) {
+	options := gloop.CSVOptions{}
+	gloop.WithCSVComment('#')(&options)
+	require.Equal(t, '#', options.Comment)
+}
+
+func TestCSVError(t *testing.T) {
+	err := &gloop.CSVError{
+		Line:   3,
+		Column: "legs",
+		Err:    errCSVTextTest,
+	}
+
+	require.Equal(t, `line 3, column "legs": csv text test error`, err.Error())
+	require.ErrorIs(t, err, errCSVTextTest)
+}
+
+func TestCSVRecords(t *testing.T) {
+	r := strings.NewReader("CAT,3\n# comment\nDOG;1\n\"MOUSE\";4\n")
+	wantRecords := [][]string{
+		{"CAT,3"},
+		{"DOG", "1"},
+		{"MOUSE", "4"},
+	}
+	i := 0
+
+	for record, err := range gloop.CSVRecords(r, gloop.WithCSVComma(';'), gloop.WithCSVComment('#')) {
+		require.NoError(t, err)
+		require.Equal(t, wantRecords[i], record)
+
+		i++
+	}
+
+	require.Equal(t, len(wantRecords), i)
+}
+
+func TestCSVRecordsParseError(t *testing.T) {
+	r := strings.NewReader("CAT,3\nDOG,\"1\nMOUSE,4\n")
+	i := 0
+
+	for record, err := range gloop.CSVRecords(r) {
+		if i == 0 {
+			require.NoError(t, err)
+			require.Equal(t, []string{"CAT", "3"}, record)
+		} else {
+			var parseErr *csv.ParseError
+			require.ErrorAs(t, err, &parseErr)
+			require.Equal(t, 2, parseErr.StartLine)
+		}
+
+		i++
+	}
+
+	require.Equal(t, 2, i)
+}
+
+func TestCSVRecordsReadError(t *testing.T) {
+	r := io.MultiReader(strings.NewReader("CAT,3\n"), iotest.ErrReader(errReaderTest))
+	i := 0
+
+	for record, err := range gloop.CSVRecords(r) {
+		if i == 0 {
+			require.NoError(t, err)
+			require.Equal(t, []string{"CAT", "3"}, record)
+		} else {
+			require.Nil(t, record)
+			require.ErrorIs(t, err, errReaderTest)
+		}
+
+		i++
+	}
+
+	require.Equal(t, 2, i)
+}
+
+func TestCSVRecordsBreak(t *testing.T) {
+	r := strings.NewReader("CAT,3\nDOG,1\n")
+
+	for record, err := range gloop.CSVRecords(r) {
+		require.NoError(t, err)
+		require.Equal(t, []string{"CAT", "3"}, record)
+
+		break
+	}
+}
+
+func TestCSV(t *testing.T) {
+	r := strings.NewReader("legs,name,Domestic,extra,Ignored\n4,CAT,true,x,y\n2,CHICKEN,false,x,y\n4,BEAR\n")
+	wantAnimals := []csvAnimal{
+		{Name: "CAT", Legs: 4, Domestic: true},
+		{Name: "CHICKEN", Legs: 2, Domestic: false},
+		{Name: "BEAR", Legs: 4},
+	}
+	i := 0
+
+	for animal, err := range gloop.CSV[csvAnimal](r) {
+		require.NoError(t, err)
+		require.Equal(t, wantAnimals[i], animal)
+
+		i++
+	}
+
+	require.Equal(t, len(wantAnimals), i)
+}
+
+func TestCSVEmbedded(t *testing.T) {
+	r := strings.NewReader("color,name,legs\nblack,CAT,4\n")
+
+	values := gloop.ToSlice(gloop.Keys(gloop.CSV[csvEmbedded](r)))
+	require.Equal(t, []csvEmbedded{
+		{csvAnimal: csvAnimal{Name: "CAT", Legs: 4}, Color: "black"},
+	}, values)
+}
+
+func TestCSVEmbeddedPointer(t *testing.T) {
+	r := strings.NewReader("color,shade\nblack,dark\nwhite,\n")
+
+	values := make([]csvEmbeddedPointer, 0)
+	for value, err := range gloop.CSV[csvEmbeddedPointer](r) {
+		require.NoError(t, err)
+
+		values = append(values, value)
+	}
+
+	require.Equal(t, []csvEmbeddedPointer{
+		{CSVShade: &CSVShade{Shade: "dark"}, Color: "black"},
+		{CSVShade: &CSVShade{Shade: ""}, Color: "white"},
+	}, values)
+}
+
+func TestCSVEmbeddedUnexportedPointerError(t *testing.T) {
+	r := strings.NewReader("color,name\nblack,CAT\n")
+	i := 0
+
+	for value, err := range gloop.CSV[csvEmbeddedPointer](r) {
+		var csvErr *gloop.CSVError
+		require.ErrorAs(t, err, &csvErr)
+		require.Equal(t, 2, csvErr.Line)
+		require.Equal(t, "name", csvErr.Column)
+		require.Equal(t, csvEmbeddedPointer{}, value)
+
+		i++
+	}
+
+	require.Equal(t, 1, i)
+}
+
+func TestCSVTypes(t *testing.T) {
+	r := strings.NewReader("Int8,Uint16,Float32,Time\n-3,1,4.5,2025-01-01T00:00:00Z\n")
+	i := 0
+
+	for value, err := range gloop.CSV[csvTypes](r) {
+		require.NoError(t, err)
+		require.Equal(t, csvTypes{
+			Int8:    -3,
+			Uint16:  1,
+			Float32: 4.5,
+			Time:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		}, value)
+
+		i++
+	}
+
+	require.Equal(t, 1, i)
+}
+
+func TestCSVDecodeErrors(t *testing.T) {
+	testcases := map[string]string{
+		"Bool":     "name,Domestic\nCAT,maybe\n",
+		"Int":      "name,legs\nCAT,four\n",
+		"Uint":     "Uint16\n-1\n",
+		"Float":    "weight\nheavy\n",
+		"Text":     "Time\nyesterday\n",
+		"Overflow": "Int8\n1000\n",
+	}
+
+	for name, input := range testcases {
+		t.Run(name, func(t *testing.T) {
+			var gotErr error
+
+			if strings.HasPrefix(input, "name") || strings.HasPrefix(input, "weight") {
+				for animal, err := range gloop.CSV[csvAnimal](strings.NewReader(input)) {
+					require.Equal(t, csvAnimal{}, animal)
+
+					gotErr = err
+				}
+			} else {
+				for value, err := range gloop.CSV[csvTypes](strings.NewReader(input)) {
+					require.Equal(t, csvTypes{}, value)
+
+					gotErr = err
+				}
+			}
+
+			var csvErr *gloop.CSVError
+			require.ErrorAs(t, gotErr, &csvErr)
+			require.Equal(t, 2, csvErr.Line)
+		})
+	}
+}
+
+func TestCSVDecodeErrorContinues(t *testing.T) {
+	r := strings.NewReader("name,legs\nCAT,4\nDOG,four\nMOUSE,4\n")
+	wantNames := []string{"CAT", "", "MOUSE"}
+	wantLines := []int{0, 3, 0}
+	i := 0
+
+	for animal, err := range gloop.CSV[csvAnimal](r) {
+		require.Equal(t, wantNames[i], animal.Name)
+
+		if wantLines[i] == 0 {
+			require.NoError(t, err)
+		} else {
+			var csvErr *gloop.CSVError
+			require.ErrorAs(t, err, &csvErr)
+			require.Equal(t, wantLines[i], csvErr.Line)
+			require.Equal(t, "legs", csvErr.Column)
+			require.ErrorIs(t, err, strconv.ErrSyntax)
+		}
+
+		i++
+	}
+
+	require.Equal(t, len(wantNames), i)
+}
+
+func TestCSVUnsupportedType(t *testing.T) {
+	r := strings.NewReader("Values\n1\n")
+
+	for _, err := range gloop.CSV[csvUnsupported](r) {
+		require.Error(t, err)
+	}
+}
+
+func TestCSVUnmarshalTextError(t *testing.T) {
+	type textError struct {
+		Text csvTextError
+	}
+
+	r := strings.NewReader("Text\nCAT\n")
+
+	for _, err := range gloop.CSV[textError](r) {
+		require.ErrorIs(t, err, errCSVTextTest)
+	}
+}
+
+func TestCSVParseError(t *testing.T) {
+	r := strings.NewReader("name,legs\n\"CAT,4\n")
+
+	for _, err := range gloop.CSV[csvAnimal](r) {
+		var parseErr *csv.ParseError
+		require.ErrorAs(t, err, &parseErr)
+	}
+}
+
+func TestCSVParseErrorBreak(t *testing.T) {
+	r := strings.NewReader("name,legs\nCAT,\"4\"x\nDOG,1\n")
+
+	for _, err := range gloop.CSV[csvAnimal](r) {
+		require.Error(t, err)
+
+		break
+	}
+}
+
+func TestCSVEmpty(t *testing.T) {
+	for range gloop.CSV[csvAnimal](strings.NewReader("")) {
+		t.Fatal("expected no iteration")
+	}
+}
+
+func TestCSVHeaderReadError(t *testing.T) {
+	i := 0
+
+	for animal, err := range gloop.CSV[csvAnimal](iotest.ErrReader(errReaderTest)) {
+		require.Equal(t, csvAnimal{}, animal)
+		require.ErrorIs(t, err, errReaderTest)
+
+		i++
+	}
+
+	require.Equal(t, 1, i)
+}
+
+func TestCSVReadError(t *testing.T) {
+	r := io.MultiReader(strings.NewReader("name\nCAT\n"), iotest.ErrReader(errReaderTest))
+	var gotErr error
+
+	for _, err := range gloop.CSV[csvAnimal](r) {
+		gotErr = err
+	}
+
+	require.ErrorIs(t, gotErr, errReaderTest)
+}
+
+func TestCSVBreak(t *testing.T) {
+	r := strings.NewReader("name\nCAT\nDOG\n")
+
+	for animal, err := range gloop.CSV[csvAnimal](r) {
+		require.NoError(t, err)
+		require.Equal(t, "CAT", animal.Name)
+
+		break
+	}
+}
+
+func TestCSVNonStructPanics(t *testing.T) {
+	require.Panics(t, func() {
+		for range gloop.CSV[int](strings.NewReader("")) {
+			t.Fatal("expected no iteration")
+		}
+	})
+}
+
+func TestToCSVRecords(t *testing.T) {
+	var b bytes.Buffer
+	records := [][]string{
+		{"CAT", "3"},
+		{"DOG", "1"},
+	}
+
+	err := gloop.ToCSVRecords(&b, gloop.Slice(records), gloop.WithCSVComma(';'))
+	require.NoError(t, err)
+	require.Equal(t, "CAT;3\nDOG;1\n", b.String())
+}
+
+func TestToCSVRecordsInvalidComma(t *testing.T) {
+	var b bytes.Buffer
+
+	err := gloop.ToCSVRecords(&b, gloop.Collect([]string{"CAT"}), gloop.WithCSVComma('"'))
+	require.Error(t, err)
+}
+
+func TestToCSVRecordsWriteError(t *testing.T) {
+	err := gloop.ToCSVRecords(failingWriter{}, gloop.Collect([]string{"CAT"}))
+	require.ErrorIs(t, err, errWriterTest)
+}
+
+func TestToCSV(t *testing.T) {
+	var b bytes.Buffer
+	animals := []csvAnimal{
+		{Name: "CAT", Legs: 4, Weight: 4.5, Domestic: true, Ignored: "x"},
+		{Name: "CHICKEN", Legs: 2, Weight: 2, Domestic: false, Ignored: "y"},
+	}
+
+	err := gloop.ToCSV(&b, gloop.Slice(animals))
+	require.NoError(t, err)
+	require.Equal(t, "name,legs,weight,Domestic\nCAT,4,4.5,true\nCHICKEN,2,2,false\n", b.String())
+}
+
+func TestToCSVRoundTrip(t *testing.T) {
+	var b bytes.Buffer
+	values := []csvTypes{
+		{Int8: -3, Uint16: 1, Float32: 4.5, Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	err := gloop.ToCSV(&b, gloop.Slice(values))
+	require.NoError(t, err)
+
+	gotValues := make([]csvTypes, 0)
+	for value, err := range gloop.CSV[csvTypes](&b) {
+		require.NoError(t, err)
+
+		gotValues = append(gotValues, value)
+	}
+
+	require.Equal(t, values, gotValues)
+}
+
+func TestToCSVEncodeError(t *testing.T) {
+	var b bytes.Buffer
+
+	err := gloop.ToCSV(&b, gloop.Collect(csvUnsupported{Values: []int{1}}))
+	require.Error(t, err)
+}
+
+func TestToCSVMarshalTextError(t *testing.T) {
+	type textError struct {
+		Text csvTextError
+	}
+
+	var b bytes.Buffer
+
+	err := gloop.ToCSV(&b, gloop.Collect(textError{}))
+	require.ErrorIs(t, err, errCSVTextTest)
+}
+
+func TestToCSVWriteError(t *testing.T) {
+	err := gloop.ToCSV(failingWriter{}, gloop.Collect(csvAnimal{}))
+	require.ErrorIs(t, err, errWriterTest)
+}
+
+func TestToCSVNonStructPanics(t *testing.T) {
+	require.Panics(t, func() {
+		_ = gloop.ToCSV(io.Discard, gloop.Collect(1))
+	})
+}
+
+func TestToCSV2(t *testing.T) {
+	var b bytes.Buffer
+	seq := func(yield func(string, int) bool) {
+		_ = yield("CAT", 3) && yield("DOG", 1)
+	}
+
+	err := gloop.ToCSV2(&b, seq)
+	require.NoError(t, err)
+	require.Equal(t, "CAT,3\nDOG,1\n", b.String())
+}
+
+func TestToCSV2KeyEncodeError(t *testing.T) {
+	var b bytes.Buffer
+	seq := func(yield func([]int, int) bool) {
+		yield([]int{3}, 1)
+	}
+
+	err := gloop.ToCSV2(&b, seq)
+	require.Error(t, err)
+}
+
+func TestToCSV2ValueEncodeError(t *testing.T) {
+	var b bytes.Buffer
+	seq := func(yield func(int, []int) bool) {
+		yield(3, []int{1})
+	}
+
+	err := gloop.ToCSV2(&b, seq)
+	require.Error(t, err)
+}
+
+func TestToCSV2WriteError(t *testing.T) {
+	seq := func(yield func(string, int) bool) {
+		yield("CAT", 3)
+	}
+
+	err := gloop.ToCSV2(failingWriter{}, seq)
+	require.ErrorIs(t, err, errWriterTest)
+}
+
+func TestToCSVEmbedded(t *testing.T) {
+	var b bytes.Buffer
+	values := []csvEmbedded{
+		{csvAnimal: csvAnimal{Name: "CAT", Legs: 4}, Color: "black"},
+	}
+
+	err := gloop.ToCSV(&b, gloop.Slice(values))
+	require.NoError(t, err)
+	require.Equal(t, "name,legs,weight,Domestic,color\nCAT,4,0,false,black\n", b.String())
+}
+
+func TestToCSVEmbeddedPointer(t *testing.T) {
+	var b bytes.Buffer
+	values := []csvEmbeddedPointer{
+		{CSVShade: &CSVShade{Shade: "dark"}, csvAnimal: &csvAnimal{Name: "CAT", Legs: 4}, Color: "black"},
+		{CSVShade: nil, csvAnimal: nil, Color: "white"},
+	}
+
+	err := gloop.ToCSV(&b, gloop.Slice(values))
+	require.NoError(t, err)
+	require.Equal(t, "shade,name,legs,weight,Domestic,color\ndark,CAT,4,0,false,black\n,,,,,white\n", b.String())
+}
+
+func TestToCSVInvalidComma(t *testing.T) {
+	err := gloop.ToCSV(io.Discard, gloop.Collect(csvAnimal{}), gloop.WithCSVComma('"'))
+	require.Error(t, err)
+
+	err = gloop.ToCSV(io.Discard, gloop.Collect(csvAnimal{}), gloop.WithCSVComma('\n'))
+	require.Error(t, err)
+}
+
+func TestToCSV2InvalidComma(t *testing.T) {
+	seq := func(yield func(string, int) bool) {
+		_ = yield("CAT", 3) && yield("DOG", 1)
+	}
+
+	err := gloop.ToCSV2(io.Discard, seq, gloop.WithCSVComma('"'))
+	require.Error(t, err)
+}
+
+func TestToCSVLargeWriteError(t *testing.T) {
+	animals := []csvAnimal{
+		{Name: strings.Repeat("CAT", 2000)},
+		{Name: "DOG"},
+	}
+
+	err := gloop.ToCSV(failingWriter{}, gloop.Slice(animals))
+	require.ErrorIs(t, err, errWriterTest)
+}
@@ -0,0 +1,336 @@
+package gloop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrDAGDuplicateTask is the error returned by [DAG] when two tasks
+// have the same key.
+var ErrDAGDuplicateTask = errors.New("duplicate task")
+
+// ErrDAGUnknownTask is the error returned by [DAG] when a task depends
+// on a key that no task has.
+var ErrDAGUnknownTask = errors.New("unknown task")
+
+// ErrDAGCycle is the error returned by [DAG] when tasks depend on each
+// other in a cycle.
+var ErrDAGCycle = errors.New("dependency cycle")
+
+// DAGTaskFunc is the function signature of the tasks run in [DAG].
+type DAGTaskFunc func(context.Context) error
+
+// DAGTask is a task run by [DAG] once the tasks it depends on succeed.
+type DAGTask[K comparable] struct {
+	// Key identifies the task.
+	Key K
+	// DependsOn holds the keys of the tasks that must succeed before
+	// the task runs.
+	DependsOn []K
+	// Run is the function run for the task.
+	Run DAGTaskFunc
+}
+
+// DAGStatus is the outcome of a task in [DAG].
+type DAGStatus int
+
+const (
+	// DAGSucceeded indicates that the task ran and returned no error.
+	DAGSucceeded DAGStatus = iota
+	// DAGFailed indicates that the task ran and returned an error.
+	DAGFailed
+	// DAGSkipped indicates that the task did not run, because a task
+	// it depends on did not succeed or the context was cancelled.
+	DAGSkipped
+)
+
+// String returns the name of the status.
+func (s DAGStatus) String() string {
+	switch s {
+	case DAGSucceeded:
+		return "succeeded"
+	case DAGFailed:
+		return "failed"
+	case DAGSkipped:
+		return "skipped"
+	default:
+		return fmt.Sprintf("DAGStatus(%d)", int(s))
+	}
+}
+
+// DAGEvent is the completion event of a task in [DAG].
+type DAGEvent[K comparable] struct {
+	// Key identifies the task.
+	Key K
+	// Status is the outcome of the task.
+	Status DAGStatus
+	// Err is the error returned by a failed task, or the reason a
+	// skipped task did not run.
+	Err error
+}
+
+// DAG runs tasks that depend on each other on separate goroutines and
+// allows looping over their completion events. Each task runs as soon
+// as the tasks it depends on succeed, and tasks that do not depend on
+// each other run concurrently. Tasks and their dependencies are
+// checked before anything runs, and an error wrapping
+// [ErrDAGDuplicateTask], [ErrDAGUnknownTask] or [ErrDAGCycle] is
+// returned if they are invalid. Tasks that depend on a task that does
+// not succeed are skipped. By default, the first failure also cancels
+// the context and every task that has not started is skipped. If
+// configured to join errors, tasks that do not depend on failed tasks
+// keep running. Once looping ends or the context is cancelled, tasks
+// that have not started are skipped, and looping ends once the tasks
+// in progress return. Weight and priority functions are called with
+// the ready tasks.
+func DAG[K comparable](
+	tasks []DAGTask[K],
+	opts ...ParallelizeOptionFunc,
+) (iter.Seq[DAGEvent[K]], error) {
+	options := newParallelizeOptions(opts...)
+	_, _ = parallelizeValueFuncs[DAGTask[K]](options)
+
+	indices := make(map[K]int, len(tasks))
+	for i, task := range tasks {
+		if _, ok := indices[task.Key]; ok {
+			return nil, fmt.Errorf("task %v: %w", task.Key, ErrDAGDuplicateTask)
+		}
+
+		indices[task.Key] = i
+	}
+
+	// dependents holds the indices of the tasks that depend on each
+	// task, and prerequisites holds the number of tasks each task
+	// depends on.
+	dependents := make([][]int, len(tasks))
+	prerequisites := make([]int, len(tasks))
+
+	for i, task := range tasks {
+		for _, key := range task.DependsOn {
+			j, ok := indices[key]
+			if !ok {
+				return nil, fmt.Errorf("task %v depends on %v: %w", task.Key, key, ErrDAGUnknownTask)
+			}
+
+			dependents[j] = append(dependents[j], i)
+			prerequisites[i]++
+		}
+	}
+
+	err := checkDAGCycles(tasks, indices, dependents, prerequisites)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(DAGEvent[K]) bool) {
+		ctx, cancel := context.WithCancelCause(options.Context)
+
+		ready := make(chan int, len(tasks))
+		events := make(chan DAGEvent[K], len(tasks))
+		done := make(chan struct{})
+
+		var mu sync.Mutex
+		waiting := slices.Clone(prerequisites)
+		finished := make([]bool, len(tasks))
+		remaining := len(tasks)
+		panicErrs := make([]*PanicError, 0)
+
+		// finish records the outcome of a task, skips the tasks that
+		// depend on it if it did not succeed, and marks the tasks that
+		// only waited for it as ready. It must be called with mu held.
+		var finish func(i int, status DAGStatus, err error)
+		finish = func(i int, status DAGStatus, err error) {
+			finished[i] = true
+			remaining--
+
+			events <- DAGEvent[K]{
+				Key:    tasks[i].Key,
+				Status: status,
+				Err:    err,
+			}
+
+			for _, j := range dependents[i] {
+				if finished[j] {
+					continue
+				}
+
+				if status != DAGSucceeded {
+					finish(j, DAGSkipped, fmt.Errorf("dependency %v did not succeed: %w", tasks[i].Key, err))
+
+					continue
+				}
+
+				waiting[j]--
+				if waiting[j] == 0 {
+					ready <- j
+				}
+			}
+		}
+
+		for i := range tasks {
+			if waiting[i] == 0 {
+				ready <- i
+			}
+		}
+
+		if len(tasks) == 0 {
+			close(ready)
+		}
+
+		defer func() {
+			cancel(nil)
+			<-done
+			reportParallelizePanics(options, panicErrs)
+		}()
+
+		go func() {
+			defer close(done)
+			defer close(events)
+
+			readyTasks := func(yield func(int, DAGTask[K]) bool) {
+				for {
+					select {
+					case i, ok := <-ready:
+						if !ok || !yield(i, tasks[i]) {
+							return
+						}
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+
+			parallelizeOpts := append(
+				slices.Clone(opts),
+				WithParallelizeContext(ctx),
+				WithParallelizeJoinErrors(true),
+				WithParallelizeRecoverPanics(false),
+				WithParallelizePanicHandler(nil),
+				WithParallelizeRepanic(false),
+				func(o *ParallelizeOptions) {
+					o.Retry = nil
+				},
+			)
+
+			startRetryPolicy(options.Retry)
+
+			_ = ParallelizeErr2(readyTasks, func(ctx context.Context, i int, task DAGTask[K]) error {
+				panicErr, err := runParallelizeFunc(ctx, options, i, task.Run)
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				if panicErr != nil {
+					panicErrs = append(panicErrs, panicErr)
+				}
+
+				if err == nil {
+					finish(i, DAGSucceeded, nil)
+				} else {
+					finish(i, DAGFailed, err)
+
+					if !options.JoinErrors {
+						cancel(fmt.Errorf("task %v failed: %w", task.Key, err))
+					}
+				}
+
+				if remaining == 0 {
+					close(ready)
+				}
+
+				return nil
+			}, parallelizeOpts...)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			for i, task := range tasks {
+				if finished[i] {
+					continue
+				}
+
+				events <- DAGEvent[K]{
+					Key:    task.Key,
+					Status: DAGSkipped,
+					Err:    context.Cause(ctx),
+				}
+			}
+		}()
+
+		for event := range events {
+			if !yield(event) {
+				return
+			}
+		}
+	}, nil
+}
+
+// checkDAGCycles returns an error describing a dependency cycle, if
+// there is one.
+func checkDAGCycles[K comparable](
+	tasks []DAGTask[K],
+	indices map[K]int,
+	dependents [][]int,
+	prerequisites []int,
+) error {
+	waiting := slices.Clone(prerequisites)
+	queue := make([]int, 0, len(tasks))
+
+	for i := range tasks {
+		if waiting[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+
+	for n := 0; n < len(queue); n++ {
+		for _, j := range dependents[queue[n]] {
+			waiting[j]--
+			if waiting[j] == 0 {
+				queue = append(queue, j)
+			}
+		}
+	}
+
+	if len(queue) == len(tasks) {
+		return nil
+	}
+
+	// every task left waiting depends on another task left waiting, so
+	// following those dependencies from any of them leads to a cycle.
+	i := slices.IndexFunc(waiting, func(n int) bool {
+		return n > 0
+	})
+	visited := make(map[int]int)
+	path := make([]int, 0)
+
+	for {
+		if start, ok := visited[i]; ok {
+			path = append(path[start:], i)
+
+			break
+		}
+
+		visited[i] = len(path)
+		path = append(path, i)
+
+		for _, key := range tasks[i].DependsOn {
+			if j := indices[key]; waiting[j] > 0 {
+				i = j
+
+				break
+			}
+		}
+	}
+
+	keys := make([]string, len(path))
+	for n, i := range path {
+		keys[n] = fmt.Sprint(tasks[i].Key)
+	}
+
+	return fmt.Errorf("%w: %s", ErrDAGCycle, strings.Join(keys, " -> "))
+}
@@ -0,0 +1,569 @@
+package gloop_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+func dagStatuses(events []gloop.DAGEvent[string]) map[string]gloop.DAGStatus {
+	statuses := make(map[string]gloop.DAGStatus)
+	for _, event := range events {
+		statuses[event.Key] = event.Status
+	}
+
+	return statuses
+}
+
+func TestDAG(t *testing.T) {
+	var finished atomic.Int64
+
+	run := func(_ context.Context) error {
+		time.Sleep(time.Millisecond * 5)
+		finished.Add(1)
+
+		return nil
+	}
+
+	tasks := []gloop.DAGTask[string]{
+		{Key: "Fizz", DependsOn: []string{"Buzz", "Bazz"}, Run: run},
+		{Key: "Buzz", DependsOn: []string{"Foo"}, Run: run},
+		{Key: "Bazz", DependsOn: []string{"Foo"}, Run: run},
+		{Key: "Foo", DependsOn: nil, Run: run},
+		{Key: "Bar", DependsOn: nil, Run: run},
+	}
+
+	seq, err := gloop.DAG(tasks)
+	require.NoError(t, err)
+
+	events := gloop.ToSlice(seq)
+	require.Len(t, events, len(tasks))
+	require.EqualValues(t, len(tasks), finished.Load())
+
+	position := make(map[string]int)
+	for i, event := range events {
+		require.Equal(t, gloop.DAGSucceeded, event.Status)
+		require.NoError(t, event.Err)
+
+		position[event.Key] = i
+	}
+
+	require.Less(t, position["Foo"], position["Buzz"])
+	require.Less(t, position["Foo"], position["Bazz"])
+	require.Less(t, position["Buzz"], position["Fizz"])
+	require.Less(t, position["Bazz"], position["Fizz"])
+}
+
+func TestDAGEmpty(t *testing.T) {
+	seq, err := gloop.DAG([]gloop.DAGTask[string]{})
+	require.NoError(t, err)
+
+	for range seq {
+		t.Fatal("expected no iteration")
+	}
+}
+
+func TestDAGMaxThreads(t *testing.T) {
+	var (
+		concurrentCallers    atomic.Int64
+		maxConcurrentCallers atomic.Int64
+	)
+
+	run := func(_ context.Context) error {
+		n := concurrentCallers.Add(1)
+		defer concurrentCallers.Add(-1)
+
+		for {
+			m := maxConcurrentCallers.Load()
+			if n <= m || maxConcurrentCallers.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond * 5)
+
+		return nil
+	}
+
+	tasks := make([]gloop.DAGTask[int], 20)
+	for i := range tasks {
+		tasks[i] = gloop.DAGTask[int]{Key: i, Run: run}
+	}
+
+	seq, err := gloop.DAG(tasks, gloop.WithParallelizeMaxThreads(3))
+	require.NoError(t, err)
+
+	events := gloop.ToSlice(seq)
+	require.Len(t, events, len(tasks))
+	require.EqualValues(t, 3, maxConcurrentCallers.Load())
+}
+
+func TestDAGInvalidTasks(t *testing.T) {
+	run := func(_ context.Context) error {
+		return nil
+	}
+
+	testcases := map[string]struct {
+		tasks         []gloop.DAGTask[string]
+		wantErr       error
+		wantErrString string
+	}{
+		"Duplicate task": {
+			tasks: []gloop.DAGTask[string]{
+				{Key: "Fizz", Run: run},
+				{Key: "Buzz", Run: run},
+				{Key: "Fizz", Run: run},
+			},
+			wantErr:       gloop.ErrDAGDuplicateTask,
+			wantErrString: "task Fizz: duplicate task",
+		},
+		"Unknown task": {
+			tasks: []gloop.DAGTask[string]{
+				{Key: "Fizz", DependsOn: []string{"Bazz"}, Run: run},
+				{Key: "Buzz", Run: run},
+			},
+			wantErr:       gloop.ErrDAGUnknownTask,
+			wantErrString: "task Fizz depends on Bazz: unknown task",
+		},
+		"Self cycle": {
+			tasks: []gloop.DAGTask[string]{
+				{Key: "Fizz", DependsOn: []string{"Fizz"}, Run: run},
+			},
+			wantErr:       gloop.ErrDAGCycle,
+			wantErrString: "dependency cycle: Fizz -> Fizz",
+		},
+		"Cycle": {
+			tasks: []gloop.DAGTask[string]{
+				{Key: "Fizz", DependsOn: []string{"Bazz"}, Run: run},
+				{Key: "Buzz", DependsOn: []string{"Fizz"}, Run: run},
+				{Key: "Bazz", DependsOn: []string{"Buzz"}, Run: run},
+			},
+			wantErr:       gloop.ErrDAGCycle,
+			wantErrString: "dependency cycle: Fizz -> Bazz -> Buzz -> Fizz",
+		},
+		"Task depending on cycle": {
+			tasks: []gloop.DAGTask[string]{
+				{Key: "Foo", Run: run},
+				{Key: "Bar", DependsOn: []string{"Foo", "Fizz"}, Run: run},
+				{Key: "Fizz", DependsOn: []string{"Buzz"}, Run: run},
+				{Key: "Buzz", DependsOn: []string{"Foo", "Fizz"}, Run: run},
+			},
+			wantErr:       gloop.ErrDAGCycle,
+			wantErrString: "dependency cycle: Fizz -> Buzz -> Fizz",
+		},
+	}
+
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			seq, err := gloop.DAG(testcase.tasks)
+			require.Nil(t, seq)
+			require.ErrorIs(t, err, testcase.wantErr)
+			require.EqualError(t, err, testcase.wantErrString)
+		})
+	}
+}
+
+func TestDAGJoinErrorsSkipsDependents(t *testing.T) {
+	errFizz := errors.New("fizz error")
+	errBuzz := errors.New("buzz error")
+
+	var called atomic.Int64
+
+	tasks := []gloop.DAGTask[string]{
+		{Key: "Fizz", Run: func(_ context.Context) error {
+			called.Add(1)
+
+			return errFizz
+		}},
+		{Key: "Buzz", Run: func(_ context.Context) error {
+			called.Add(1)
+
+			return errBuzz
+		}},
+		{Key: "Bazz", DependsOn: []string{"Fizz", "Buzz"}, Run: func(_ context.Context) error {
+			t.Fatal("expected Bazz to be skipped")
+
+			return nil
+		}},
+		{Key: "Foo", DependsOn: []string{"Bazz"}, Run: func(_ context.Context) error {
+			t.Fatal("expected Foo to be skipped")
+
+			return nil
+		}},
+		{Key: "Bar", Run: func(_ context.Context) error {
+			called.Add(1)
+
+			return nil
+		}},
+	}
+
+	seq, err := gloop.DAG(
+		tasks,
+		gloop.WithParallelizeJoinErrors(true),
+		gloop.WithParallelizeMaxThreads(1),
+	)
+	require.NoError(t, err)
+
+	events := gloop.ToSlice(seq)
+	require.Len(t, events, len(tasks))
+	require.EqualValues(t, 3, called.Load())
+
+	require.Equal(t, map[string]gloop.DAGStatus{
+		"Fizz": gloop.DAGFailed,
+		"Buzz": gloop.DAGFailed,
+		"Bazz": gloop.DAGSkipped,
+		"Foo":  gloop.DAGSkipped,
+		"Bar":  gloop.DAGSucceeded,
+	}, dagStatuses(events))
+
+	for _, event := range events {
+		switch event.Key {
+		case "Fizz":
+			require.Equal(t, errFizz, event.Err)
+		case "Buzz":
+			require.Equal(t, errBuzz, event.Err)
+		case "Bazz":
+			require.ErrorIs(t, event.Err, errFizz)
+			require.EqualError(t, event.Err, "dependency Fizz did not succeed: fizz error")
+		case "Foo":
+			require.ErrorIs(t, event.Err, errFizz)
+			require.EqualError(t, event.Err, "dependency Bazz did not succeed: dependency Fizz did not succeed: fizz error")
+		case "Bar":
+			require.NoError(t, event.Err)
+		}
+	}
+}
+
+func TestDAGFailureCancels(t *testing.T) {
+	errFizz := errors.New("fizz error")
+	started := make(chan struct{})
+
+	tasks := []gloop.DAGTask[string]{
+		{Key: "Fizz", Run: func(_ context.Context) error {
+			<-started
+
+			return errFizz
+		}},
+		{Key: "Buzz", DependsOn: []string{"Fizz"}, Run: func(_ context.Context) error {
+			t.Fatal("expected Buzz to be skipped")
+
+			return nil
+		}},
+		{Key: "Bazz", DependsOn: []string{"Foo"}, Run: func(_ context.Context) error {
+			t.Fatal("expected Bazz to be skipped")
+
+			return nil
+		}},
+		{Key: "Foo", Run: func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+
+			return context.Cause(ctx)
+		}},
+	}
+
+	seq, err := gloop.DAG(tasks)
+	require.NoError(t, err)
+
+	done := make(chan []gloop.DAGEvent[string], 1)
+	go func() {
+		done <- gloop.ToSlice(seq)
+	}()
+
+	select {
+	case events := <-done:
+		require.Len(t, events, len(tasks))
+		require.Equal(t, map[string]gloop.DAGStatus{
+			"Fizz": gloop.DAGFailed,
+			"Buzz": gloop.DAGSkipped,
+			"Bazz": gloop.DAGSkipped,
+			"Foo":  gloop.DAGFailed,
+		}, dagStatuses(events))
+
+		for _, event := range events {
+			require.ErrorIs(t, event.Err, errFizz)
+		}
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestDAGCancelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tasks := []gloop.DAGTask[string]{
+		{Key: "Fizz", Run: func(_ context.Context) error {
+			t.Fatal("expected Fizz to be skipped")
+
+			return nil
+		}},
+		{Key: "Buzz", DependsOn: []string{"Fizz"}, Run: func(_ context.Context) error {
+			t.Fatal("expected Buzz to be skipped")
+
+			return nil
+		}},
+	}
+
+	seq, err := gloop.DAG(tasks, gloop.WithParallelizeContext(ctx))
+	require.NoError(t, err)
+
+	events := gloop.ToSlice(seq)
+	require.Equal(t, []gloop.DAGEvent[string]{
+		{Key: "Fizz", Status: gloop.DAGSkipped, Err: context.Canceled},
+		{Key: "Buzz", Status: gloop.DAGSkipped, Err: context.Canceled},
+	}, events)
+}
+
+func TestDAGBreak(t *testing.T) {
+	var started atomic.Int64
+
+	tasks := make([]gloop.DAGTask[int], 10)
+	for i := range tasks {
+		tasks[i] = gloop.DAGTask[int]{Key: i, Run: func(ctx context.Context) error {
+			started.Add(1)
+
+			if i > 0 {
+				<-ctx.Done()
+			}
+
+			return nil
+		}}
+	}
+
+	seq, err := gloop.DAG(tasks, gloop.WithParallelizeMaxThreads(2))
+	require.NoError(t, err)
+
+	done := make(chan []int, 1)
+	go func() {
+		keys := make([]int, 0)
+		for event := range seq {
+			keys = append(keys, event.Key)
+
+			break
+		}
+
+		done <- keys
+	}()
+
+	select {
+	case keys := <-done:
+		require.Equal(t, []int{0}, keys)
+		require.LessOrEqual(t, started.Load(), int64(3))
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestDAGRecoverPanics(t *testing.T) {
+	panicValues := make([]any, 0)
+
+	tasks := []gloop.DAGTask[string]{
+		{Key: "Fizz", Run: func(_ context.Context) error {
+			panic("Fizz")
+		}},
+		{Key: "Buzz", DependsOn: []string{"Fizz"}, Run: func(_ context.Context) error {
+			return nil
+		}},
+	}
+
+	seq, err := gloop.DAG(
+		tasks,
+		gloop.WithParallelizePanicHandler(func(err *gloop.PanicError) {
+			panicValues = append(panicValues, err.Value)
+		}),
+	)
+	require.NoError(t, err)
+
+	events := gloop.ToSlice(seq)
+	require.Len(t, events, len(tasks))
+	require.Equal(t, map[string]gloop.DAGStatus{
+		"Fizz": gloop.DAGFailed,
+		"Buzz": gloop.DAGSkipped,
+	}, dagStatuses(events))
+
+	var panicErr *gloop.PanicError
+	require.ErrorAs(t, events[0].Err, &panicErr)
+	require.Equal(t, "Fizz", panicErr.Value)
+	require.Equal(t, []any{"Fizz"}, panicValues)
+}
+
+func TestDAGRepanic(t *testing.T) {
+	tasks := []gloop.DAGTask[string]{
+		{Key: "Fizz", Run: func(_ context.Context) error {
+			panic("Fizz")
+		}},
+	}
+
+	seq, err := gloop.DAG(tasks, gloop.WithParallelizeRepanic(true))
+	require.NoError(t, err)
+
+	done := make(chan any, 1)
+	go func() {
+		defer func() {
+			done <- recover()
+		}()
+
+		for range seq {
+		}
+	}()
+
+	select {
+	case r := <-done:
+		var panicErr *gloop.PanicError
+		require.ErrorAs(t, r.(error), &panicErr)
+		require.Equal(t, "Fizz", panicErr.Value)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestDAGRetry(t *testing.T) {
+	report := &gloop.RetryReport{}
+
+	var attempts atomic.Int64
+
+	tasks := []gloop.DAGTask[string]{
+		{Key: "Fizz", Run: func(_ context.Context) error {
+			return nil
+		}},
+		{Key: "Buzz", DependsOn: []string{"Fizz"}, Run: func(_ context.Context) error {
+			if attempts.Add(1) < 3 {
+				return errRetryTest
+			}
+
+			return nil
+		}},
+	}
+
+	seq, err := gloop.DAG(tasks, gloop.WithParallelizeRetry(gloop.RetryPolicy{
+		MaxAttempts: 3,
+		Report:      report,
+	}))
+	require.NoError(t, err)
+
+	events := gloop.ToSlice(seq)
+	require.Equal(t, []gloop.DAGEvent[string]{
+		{Key: "Fizz", Status: gloop.DAGSucceeded},
+		{Key: "Buzz", Status: gloop.DAGSucceeded},
+	}, events)
+
+	results := report.Results()
+	slices.SortFunc(results, func(a gloop.RetryResult, b gloop.RetryResult) int {
+		return a.Index - b.Index
+	})
+	require.Equal(t, []gloop.RetryResult{
+		{Index: 0, Attempts: 1, Err: nil},
+		{Index: 1, Attempts: 3, Err: nil},
+	}, results)
+}
+
+func TestDAGValueFuncs(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		gotOrder []string
+	)
+
+	run := func(key string) gloop.DAGTaskFunc {
+		return func(_ context.Context) error {
+			mu.Lock()
+			gotOrder = append(gotOrder, key)
+			mu.Unlock()
+
+			return nil
+		}
+	}
+
+	tasks := []gloop.DAGTask[string]{
+		{Key: "Fizz", DependsOn: nil, Run: run("Fizz")},
+		{Key: "Buzz", DependsOn: []string{"Fizz"}, Run: run("Buzz")},
+		{Key: "Bazz", DependsOn: []string{"Fizz"}, Run: run("Bazz")},
+	}
+
+	var weighed, prioritized atomic.Int64
+
+	seq, err := gloop.DAG(
+		tasks,
+		gloop.WithParallelizeWeight(4, func(task gloop.DAGTask[string]) int64 {
+			weighed.Add(1)
+
+			return int64(len(task.Key))
+		}),
+		gloop.WithParallelizePriority(func(task gloop.DAGTask[string]) int {
+			prioritized.Add(1)
+
+			return len(task.Key)
+		}, len(tasks)),
+	)
+	require.NoError(t, err)
+
+	for event := range seq {
+		require.Equal(t, gloop.DAGSucceeded, event.Status)
+	}
+
+	require.Equal(t, "Fizz", gotOrder[0])
+	require.ElementsMatch(t, []string{"Fizz", "Buzz", "Bazz"}, gotOrder)
+	require.Equal(t, int64(len(tasks)), weighed.Load())
+	require.Equal(t, int64(len(tasks)), prioritized.Load())
+}
+
+func TestDAGValueFuncsTypeMismatchPanics(t *testing.T) {
+	tasks := []gloop.DAGTask[string]{
+		{Key: "Fizz", DependsOn: nil, Run: func(_ context.Context) error {
+			return nil
+		}},
+	}
+
+	require.PanicsWithValue(t, "weight function must take the sequence's value type", func() {
+		_, _ = gloop.DAG(tasks, gloop.WithParallelizeWeight(2, func(key string) int64 {
+			return int64(len(key))
+		}))
+	})
+
+	require.PanicsWithValue(t, "priority function must take the sequence's value type", func() {
+		_, _ = gloop.DAG(tasks, gloop.WithParallelizePriority(func(key string) int {
+			return len(key)
+		}, 4))
+	})
+}
+
+func TestDAGStatusString(t *testing.T) {
+	testcases := map[string]struct {
+		status     gloop.DAGStatus
+		wantString string
+	}{
+		"Succeeded": {
+			status:     gloop.DAGSucceeded,
+			wantString: "succeeded",
+		},
+		"Failed": {
+			status:     gloop.DAGFailed,
+			wantString: "failed",
+		},
+		"Skipped": {
+			status:     gloop.DAGSkipped,
+			wantString: "skipped",
+		},
+		"Unknown": {
+			status:     gloop.DAGStatus(7),
+			wantString: "DAGStatus(7)",
+		},
+	}
+
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, testcase.wantString, testcase.status.String())
+		})
+	}
+}
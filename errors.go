@@ -0,0 +1,68 @@
+package gloop
+
+import (
+	"errors"
+	"iter"
+)
+
+// ErrorOptions defines configurable options for [TransformErr],
+// [FilterErr] and [ToSliceErr].
+type ErrorOptions struct {
+	// Collect indicates whether looping continues past errors. If
+	// false, looping ends at the first error.
+	Collect bool
+}
+
+// ErrorOptionFunc is the function signature of configuration helpers
+// for [TransformErr], [FilterErr] and [ToSliceErr].
+type ErrorOptionFunc func(*ErrorOptions)
+
+// WithErrorCollect is a helper for configuring [TransformErr],
+// [FilterErr] and [ToSliceErr] to continue looping past errors.
+func WithErrorCollect(collect bool) ErrorOptionFunc {
+	return func(o *ErrorOptions) {
+		o.Collect = collect
+	}
+}
+
+// newErrorOptions returns the default [ErrorOptions] with the given
+// configuration helpers applied.
+func newErrorOptions(opts ...ErrorOptionFunc) ErrorOptions {
+	options := ErrorOptions{
+		Collect: false,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// CollectErrors allows looping over the values in an [iter.Seq2]
+// sequence of values and errors, skipping values that come with an
+// error. The returned function reports the errors skipped so far,
+// joined together, or nil if there were none.
+func CollectErrors[V any](seq iter.Seq2[V, error]) (iter.Seq[V], func() error) {
+	var errs []error
+
+	values := func(yield func(V) bool) {
+		errs = nil
+
+		for value, err := range seq {
+			if err != nil {
+				errs = append(errs, err)
+
+				continue
+			}
+
+			if !yield(value) {
+				return
+			}
+		}
+	}
+
+	return values, func() error {
+		return errors.Join(errs...)
+	}
+}
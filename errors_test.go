@@ -0,0 +1,80 @@
+package gloop_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectErrors(t *testing.T) {
+	values := []string{"3", "x", "5", "y"}
+	wantValues := []int{3, 5}
+	i := 0
+
+	seq, errFunc := gloop.CollectErrors(gloop.TransformErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(make([]error, len(values)))),
+		strconv.Atoi,
+		gloop.WithErrorCollect(true),
+	))
+
+	for value := range seq {
+		require.Equal(t, wantValues[i], value)
+
+		i++
+	}
+
+	require.Equal(t, len(wantValues), i)
+
+	err := errFunc()
+	require.ErrorIs(t, err, strconv.ErrSyntax)
+
+	var numErr *strconv.NumError
+	require.ErrorAs(t, err, &numErr)
+	require.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 2)
+}
+
+func TestCollectErrorsNoErrors(t *testing.T) {
+	values := []int{3, 4, 5}
+
+	seq, errFunc := gloop.CollectErrors(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(make([]error, len(values)))),
+	)
+	require.Equal(t, values, gloop.ToSlice(seq))
+	require.NoError(t, errFunc())
+}
+
+func TestCollectErrorsBreak(t *testing.T) {
+	errSource := errors.New("source error")
+	values := []int{3, 4, 5}
+	errs := []error{errSource, nil, errSource}
+	i := 0
+
+	seq, errFunc := gloop.CollectErrors(gloop.Zip(gloop.Slice(values), gloop.Slice(errs)))
+
+	for value := range seq {
+		require.Equal(t, 4, value)
+
+		i++
+
+		break
+	}
+
+	require.Equal(t, 1, i)
+	require.ErrorIs(t, errFunc(), errSource)
+}
+
+func TestCollectErrorsRepeated(t *testing.T) {
+	errSource := errors.New("source error")
+	values := []int{3, 4}
+	errs := []error{errSource, nil}
+
+	seq, errFunc := gloop.CollectErrors(gloop.Zip(gloop.Slice(values), gloop.Slice(errs)))
+
+	for range 2 {
+		require.Equal(t, []int{4}, gloop.ToSlice(seq))
+		require.Len(t, errFunc().(interface{ Unwrap() []error }).Unwrap(), 1)
+	}
+}
@@ -0,0 +1,296 @@
+package gloop
+
+import (
+	"iter"
+	"time"
+)
+
+// EventTimeWindowOptions defines configurable options for
+// [EventTimeWindow].
+type EventTimeWindowOptions[V any] struct {
+	// Step is the duration between the starts of consecutive windows.
+	// If zero, the step is equal to the window size, which produces
+	// tumbling windows. If larger than the window size, values in the
+	// gaps between windows are dropped without being treated as late.
+	Step time.Duration
+	// AllowedLateness is how far the watermark trails behind the
+	// latest timestamp seen so far. Values arriving out of order by
+	// less than this are still assigned to their windows.
+	AllowedLateness time.Duration
+	// Late is called with values that only belong to windows which
+	// have already been yielded. If nil, late values are dropped.
+	Late func(V)
+}
+
+// EventTimeWindowOptionFunc is the function signature of configuration
+// helpers for [EventTimeWindow].
+type EventTimeWindowOptionFunc[V any] func(*EventTimeWindowOptions[V])
+
+// WithEventTimeWindowStep is a helper for configuring the duration
+// between the starts of consecutive windows in [EventTimeWindow].
+func WithEventTimeWindowStep[V any](step time.Duration) EventTimeWindowOptionFunc[V] {
+	return func(o *EventTimeWindowOptions[V]) {
+		o.Step = step
+	}
+}
+
+// WithEventTimeWindowAllowedLateness is a helper for configuring how
+// far the watermark trails behind the latest timestamp in
+// [EventTimeWindow].
+func WithEventTimeWindowAllowedLateness[V any](lateness time.Duration) EventTimeWindowOptionFunc[V] {
+	return func(o *EventTimeWindowOptions[V]) {
+		o.AllowedLateness = lateness
+	}
+}
+
+// WithEventTimeWindowLate is a helper for configuring the function
+// called with late values in [EventTimeWindow].
+func WithEventTimeWindowLate[V any](late func(V)) EventTimeWindowOptionFunc[V] {
+	return func(o *EventTimeWindowOptions[V]) {
+		o.Late = late
+	}
+}
+
+// EventTimeWindow allows looping over an [iter.Seq] sequence of
+// possibly out of order values in windows of a given duration, based
+// on the event time of each value. A watermark trails the latest
+// timestamp seen by the allowed lateness, and each window is yielded
+// once the watermark passes its end. Windows are aligned to multiples
+// of the step and empty windows are not yielded. The duration must be
+// positive and the step must not be negative.
+func EventTimeWindow[V any](
+	seq iter.Seq[V],
+	size time.Duration,
+	timestamp TimeWindowTimestampFunc[V],
+	opts ...EventTimeWindowOptionFunc[V],
+) iter.Seq[iter.Seq[V]] {
+	options := EventTimeWindowOptions[V]{
+		Step:            0,
+		AllowedLateness: 0,
+		Late:            nil,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	opts2 := []EventTimeWindow2OptionFunc[struct{}, V]{
+		WithEventTimeWindow2Step[struct{}, V](options.Step),
+		WithEventTimeWindow2AllowedLateness[struct{}, V](options.AllowedLateness),
+	}
+
+	if options.Late != nil {
+		opts2 = append(opts2, WithEventTimeWindow2Late(func(_ struct{}, value V) {
+			options.Late(value)
+		}))
+	}
+
+	keyed := func(yield func(struct{}, V) bool) {
+		for value := range seq {
+			if !yield(struct{}{}, value) {
+				return
+			}
+		}
+	}
+
+	return Values(EventTimeWindow2(keyed, size, func(_ struct{}, value V) time.Time {
+		return timestamp(value)
+	}, opts2...))
+}
+
+// EventTimeWindow2Options defines configurable options for
+// [EventTimeWindow2].
+type EventTimeWindow2Options[K, V any] struct {
+	// Step is the duration between the starts of consecutive windows.
+	// If zero, the step is equal to the window size, which produces
+	// tumbling windows. If larger than the window size, values in the
+	// gaps between windows are dropped without being treated as late.
+	Step time.Duration
+	// AllowedLateness is how far the watermark trails behind the
+	// latest timestamp seen so far. Values arriving out of order by
+	// less than this are still assigned to their windows.
+	AllowedLateness time.Duration
+	// Late is called with keys and values that only belong to windows
+	// which have already been yielded. If nil, late keys and values
+	// are dropped.
+	Late func(K, V)
+}
+
+// EventTimeWindow2OptionFunc is the function signature of
+// configuration helpers for [EventTimeWindow2].
+type EventTimeWindow2OptionFunc[K, V any] func(*EventTimeWindow2Options[K, V])
+
+// WithEventTimeWindow2Step is a helper for configuring the duration
+// between the starts of consecutive windows in [EventTimeWindow2].
+func WithEventTimeWindow2Step[K, V any](step time.Duration) EventTimeWindow2OptionFunc[K, V] {
+	return func(o *EventTimeWindow2Options[K, V]) {
+		o.Step = step
+	}
+}
+
+// WithEventTimeWindow2AllowedLateness is a helper for configuring how
+// far the watermark trails behind the latest timestamp in
+// [EventTimeWindow2].
+func WithEventTimeWindow2AllowedLateness[K, V any](lateness time.Duration) EventTimeWindow2OptionFunc[K, V] {
+	return func(o *EventTimeWindow2Options[K, V]) {
+		o.AllowedLateness = lateness
+	}
+}
+
+// WithEventTimeWindow2Late is a helper for configuring the function
+// called with late keys and values in [EventTimeWindow2].
+func WithEventTimeWindow2Late[K, V any](late func(K, V)) EventTimeWindow2OptionFunc[K, V] {
+	return func(o *EventTimeWindow2Options[K, V]) {
+		o.Late = late
+	}
+}
+
+// EventTimeWindow2TimestampFunc is the function signature of the
+// timestamp extractor used in [EventTimeWindow2].
+type EventTimeWindow2TimestampFunc[K, V any] func(K, V) time.Time
+
+// EventTimeWindow2 allows looping over an [iter.Seq2] sequence of
+// possibly out of order keys and values in windows of a given
+// duration, based on the event time of each key and value. Values are
+// windowed separately for each key, and each window is yielded along
+// with its key. A watermark trails the latest timestamp seen by the
+// allowed lateness, and each window is yielded once the watermark
+// passes its end. Windows are aligned to multiples of the step and
+// empty windows are not yielded. The duration must be positive and the
+// step must not be negative.
+func EventTimeWindow2[K comparable, V any](
+	seq iter.Seq2[K, V],
+	size time.Duration,
+	timestamp EventTimeWindow2TimestampFunc[K, V],
+	opts ...EventTimeWindow2OptionFunc[K, V],
+) iter.Seq2[K, iter.Seq[V]] {
+	if size <= 0 {
+		panic("size must be positive")
+	}
+
+	options := EventTimeWindow2Options[K, V]{
+		Step:            0,
+		AllowedLateness: 0,
+		Late:            nil,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.Step < 0 {
+		panic("step must not be negative")
+	}
+
+	step := options.Step
+	if step == 0 {
+		step = size
+	}
+
+	return func(yield func(K, iter.Seq[V]) bool) {
+		// windows holds the open windows, ordered by start time.
+		windows := make([]*eventTimeWindow[K, V], 0)
+		var watermark time.Time
+		started := false
+
+		emit := func(flush bool) bool {
+			n := 0
+			for n < len(windows) && (flush || !windows[n].start.Add(size).After(watermark)) {
+				n++
+			}
+
+			closed := windows[:n]
+			windows = windows[n:]
+
+			for _, window := range closed {
+				for _, key := range window.keys {
+					if !yield(key, Slice(window.values[key])) {
+						return false
+					}
+				}
+			}
+
+			return true
+		}
+
+		for key, value := range seq {
+			t := timestamp(key, value)
+
+			if mark := t.Add(-options.AllowedLateness); !started || mark.After(watermark) {
+				watermark = mark
+				started = true
+			}
+
+			// matched and assigned indicate whether the value belongs
+			// to any window, and to any window still open. Values in
+			// gaps between windows belong to none.
+			matched := false
+			assigned := false
+			start := t.Add(-size).Truncate(step).Add(step)
+
+			for ; !start.After(t); start = start.Add(step) {
+				matched = true
+
+				if !start.Add(size).After(watermark) {
+					continue
+				}
+
+				assigned = true
+				windows = addEventTimeWindowValue(windows, start, key, value)
+			}
+
+			if matched && !assigned && options.Late != nil {
+				options.Late(key, value)
+			}
+
+			if !emit(false) {
+				return
+			}
+		}
+
+		emit(true)
+	}
+}
+
+// eventTimeWindow is an open window in [EventTimeWindow2].
+type eventTimeWindow[K comparable, V any] struct {
+	start  time.Time
+	keys   []K
+	values map[K][]V
+}
+
+// addEventTimeWindowValue adds a key and value to the window with a
+// given start time, creating the window if it does not exist, and
+// returns the updated windows.
+func addEventTimeWindowValue[K comparable, V any](
+	windows []*eventTimeWindow[K, V],
+	start time.Time,
+	key K,
+	value V,
+) []*eventTimeWindow[K, V] {
+	i := 0
+	for i < len(windows) && windows[i].start.Before(start) {
+		i++
+	}
+
+	if i == len(windows) || !windows[i].start.Equal(start) {
+		window := &eventTimeWindow[K, V]{
+			start:  start,
+			keys:   make([]K, 0),
+			values: make(map[K][]V),
+		}
+
+		windows = append(windows, nil)
+		copy(windows[i+1:], windows[i:])
+		windows[i] = window
+	}
+
+	window := windows[i]
+	if _, ok := window.values[key]; !ok {
+		window.keys = append(window.keys, key)
+	}
+
+	window.values[key] = append(window.values[key], value)
+
+	return windows
+}
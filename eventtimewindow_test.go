@@ -0,0 +1,286 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithEventTimeWindowStep(t *testing.T) {
+	step := time.Second
+	options := gloop.EventTimeWindowOptions[int]{}
+	gloop.WithEventTimeWindowStep[int](step)(&options)
+	require.Equal(t, step, options.Step)
+}
+
+func TestWithEventTimeWindowAllowedLateness(t *testing.T) {
+	lateness := time.Second
+	options := gloop.EventTimeWindowOptions[int]{}
+	gloop.WithEventTimeWindowAllowedLateness[int](lateness)(&options)
+	require.Equal(t, lateness, options.AllowedLateness)
+}
+
+func TestWithEventTimeWindowLate(t *testing.T) {
+	lateValues := make([]int, 0)
+	options := gloop.EventTimeWindowOptions[int]{}
+	gloop.WithEventTimeWindowLate(func(value int) {
+		lateValues = append(lateValues, value)
+	})(&options)
+
+	require.NotNil(t, options.Late)
+	options.Late(42)
+	require.Equal(t, []int{42}, lateValues)
+}
+
+func TestEventTimeWindowTumbling(t *testing.T) {
+	events := timeWindowEvents(
+		0,
+		time.Millisecond*500,
+		time.Millisecond*1200,
+		time.Millisecond*3100,
+		time.Millisecond*3900,
+	)
+	wantWindows := []string{"ab", "c", "de"}
+	i := 0
+
+	for seq := range gloop.EventTimeWindow(gloop.Slice(events), time.Second, timeWindowEventTimestamp) {
+		require.Equal(t, wantWindows[i], timeWindowNames(seq))
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestEventTimeWindowSliding(t *testing.T) {
+	events := timeWindowEvents(
+		0,
+		time.Millisecond*600,
+		time.Millisecond*1100,
+	)
+	wantWindows := []string{"a", "ab", "bc", "c"}
+	i := 0
+
+	for seq := range gloop.EventTimeWindow(
+		gloop.Slice(events),
+		time.Second,
+		timeWindowEventTimestamp,
+		gloop.WithEventTimeWindowStep[timeWindowEvent](time.Millisecond*500),
+	) {
+		require.Equal(t, wantWindows[i], timeWindowNames(seq))
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestEventTimeWindowOutOfOrder(t *testing.T) {
+	events := timeWindowEvents(
+		time.Millisecond*100,
+		time.Millisecond*1200,
+		time.Millisecond*800,
+		time.Millisecond*2500,
+		time.Millisecond*1900,
+		time.Millisecond*400,
+	)
+	wantWindows := []string{"ac", "be"}
+	wantLate := "f"
+	late := ""
+	i := 0
+
+	for seq := range gloop.EventTimeWindow(
+		gloop.Slice(events),
+		time.Second,
+		timeWindowEventTimestamp,
+		gloop.WithEventTimeWindowAllowedLateness[timeWindowEvent](time.Millisecond*700),
+		gloop.WithEventTimeWindowLate(func(event timeWindowEvent) {
+			late += event.Name
+		}),
+	) {
+		if i == len(wantWindows) {
+			require.Equal(t, "d", timeWindowNames(seq))
+
+			break
+		}
+
+		require.Equal(t, wantWindows[i], timeWindowNames(seq))
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+	require.Equal(t, wantLate, late)
+}
+
+func TestEventTimeWindowGapsNotLate(t *testing.T) {
+	events := timeWindowEvents(
+		0,
+		time.Millisecond*1500,
+		time.Millisecond*3200,
+		time.Millisecond*2500,
+		time.Millisecond*600,
+	)
+	wantWindows := []string{"a", "c"}
+	wantLate := "e"
+	late := ""
+	i := 0
+
+	for seq := range gloop.EventTimeWindow(
+		gloop.Slice(events),
+		time.Second,
+		timeWindowEventTimestamp,
+		gloop.WithEventTimeWindowStep[timeWindowEvent](time.Second*3),
+		gloop.WithEventTimeWindowLate(func(event timeWindowEvent) {
+			late += event.Name
+		}),
+	) {
+		require.Equal(t, wantWindows[i], timeWindowNames(seq))
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+	require.Equal(t, wantLate, late)
+}
+
+func TestEventTimeWindowLateDropped(t *testing.T) {
+	events := timeWindowEvents(
+		time.Millisecond*100,
+		time.Millisecond*1200,
+		time.Millisecond*400,
+	)
+	wantWindows := []string{"a", "b"}
+	i := 0
+
+	for seq := range gloop.EventTimeWindow(gloop.Slice(events), time.Second, timeWindowEventTimestamp) {
+		require.Equal(t, wantWindows[i], timeWindowNames(seq))
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestEventTimeWindowBreak(t *testing.T) {
+	events := timeWindowEvents(0, time.Millisecond*1200, time.Millisecond*2400)
+
+	for seq := range gloop.EventTimeWindow(gloop.Slice(events), time.Second, timeWindowEventTimestamp) {
+		require.Equal(t, "a", timeWindowNames(seq))
+
+		break
+	}
+}
+
+func TestEventTimeWindowZeroSizePanics(t *testing.T) {
+	require.Panics(t, func() {
+		for range gloop.EventTimeWindow(gloop.Slice([]timeWindowEvent{}), 0, timeWindowEventTimestamp) {
+			t.Fatal("expected no iteration")
+		}
+	})
+}
+
+func TestEventTimeWindowNegativeStepPanics(t *testing.T) {
+	require.Panics(t, func() {
+		for range gloop.EventTimeWindow(
+			gloop.Slice([]timeWindowEvent{}),
+			time.Second,
+			timeWindowEventTimestamp,
+			gloop.WithEventTimeWindowStep[timeWindowEvent](-time.Second),
+		) {
+			t.Fatal("expected no iteration")
+		}
+	})
+}
+
+func TestWithEventTimeWindow2Step(t *testing.T) {
+	step := time.Second
+	options := gloop.EventTimeWindow2Options[string, int]{}
+	gloop.WithEventTimeWindow2Step[string, int](step)(&options)
+	require.Equal(t, step, options.Step)
+}
+
+func TestWithEventTimeWindow2AllowedLateness(t *testing.T) {
+	lateness := time.Second
+	options := gloop.EventTimeWindow2Options[string, int]{}
+	gloop.WithEventTimeWindow2AllowedLateness[string, int](lateness)(&options)
+	require.Equal(t, lateness, options.AllowedLateness)
+}
+
+func TestWithEventTimeWindow2Late(t *testing.T) {
+	lateKeys := make([]string, 0)
+	lateValues := make([]int, 0)
+	options := gloop.EventTimeWindow2Options[string, int]{}
+	gloop.WithEventTimeWindow2Late(func(key string, value int) {
+		lateKeys = append(lateKeys, key)
+		lateValues = append(lateValues, value)
+	})(&options)
+
+	require.NotNil(t, options.Late)
+	options.Late("FizzBuzz", 42)
+	require.Equal(t, []string{"FizzBuzz"}, lateKeys)
+	require.Equal(t, []int{42}, lateValues)
+}
+
+func TestEventTimeWindow2Keyed(t *testing.T) {
+	events := timeWindowEvents(
+		0,
+		time.Millisecond*300,
+		time.Millisecond*500,
+		time.Millisecond*1200,
+		time.Millisecond*900,
+		time.Millisecond*2100,
+	)
+	keys := []string{"x", "y", "x", "y", "y", "x"}
+	seq := func(yield func(string, timeWindowEvent) bool) {
+		for i, event := range events {
+			if !yield(keys[i], event) {
+				return
+			}
+		}
+	}
+
+	wantKeys := []string{"x", "y", "y", "x"}
+	wantWindows := []string{"ac", "be", "d", "f"}
+	wantLateKeys := ""
+	lateKeys := ""
+	i := 0
+
+	for key, window := range gloop.EventTimeWindow2(
+		seq,
+		time.Second,
+		func(_ string, event timeWindowEvent) time.Time {
+			return event.Time
+		},
+		gloop.WithEventTimeWindow2AllowedLateness[string, timeWindowEvent](time.Millisecond*500),
+		gloop.WithEventTimeWindow2Late(func(key string, _ timeWindowEvent) {
+			lateKeys += key
+		}),
+	) {
+		require.Equal(t, wantKeys[i], key)
+		require.Equal(t, wantWindows[i], timeWindowNames(window))
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+	require.Equal(t, wantLateKeys, lateKeys)
+}
+
+func TestEventTimeWindow2Break(t *testing.T) {
+	seq := func(yield func(string, int) bool) {
+		_ = yield("x", 0) && yield("y", 1) && yield("x", 2000)
+	}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for key, window := range gloop.EventTimeWindow2(seq, time.Second, func(_ string, ms int) time.Time {
+		return start.Add(time.Millisecond * time.Duration(ms))
+	}) {
+		require.Equal(t, "x", key)
+		require.Equal(t, []int{0}, gloop.ToSlice(window))
+
+		break
+	}
+}
@@ -1,12 +1,18 @@
 package gloop_test
 
 import (
+	"bufio"
 	"container/list"
 	"context"
+	"errors"
 	"fmt"
 	"iter"
 	"math/rand"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"testing/fstest"
 	"time"
 
 	"github.com/alvii147/gloop"
@@ -144,6 +150,22 @@ func ExampleChannel() {
 	// MOUSE
 }
 
+func ExampleChunks() {
+	r := strings.NewReader("CATDOGMOUSE")
+	for chunk, err := range gloop.Chunks(r, 3) {
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Println(string(chunk))
+	}
+	// Output:
+	// CAT
+	// DOG
+	// MOU
+	// SE
+}
+
 func ExampleCollect() {
 	for i := range gloop.Collect(3, 1, 4) {
 		fmt.Println(i)
@@ -154,6 +176,92 @@ func ExampleCollect() {
 	// 4
 }
 
+func ExampleCollectErrors() {
+	values := []string{"3", "CAT", "5"}
+	errs := make([]error, len(values))
+
+	seq, errFunc := gloop.CollectErrors(gloop.TransformErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(errs)),
+		strconv.Atoi,
+		gloop.WithErrorCollect(true),
+	))
+
+	for value := range seq {
+		fmt.Println(value)
+	}
+
+	fmt.Println(errFunc())
+	// Output:
+	// 3
+	// 5
+	// strconv.Atoi: parsing "CAT": invalid syntax
+}
+
+func ExampleCSV() {
+	type animal struct {
+		Name string `csv:"name"`
+		Legs int    `csv:"legs"`
+	}
+
+	r := strings.NewReader("name,legs\nCAT,4\nCHICKEN,two\nMOUSE,4\n")
+	for a, err := range gloop.CSV[animal](r) {
+		if err != nil {
+			fmt.Println(err)
+
+			continue
+		}
+
+		fmt.Println(a.Name, a.Legs)
+	}
+	// Output:
+	// CAT 4
+	// line 3, column "legs": failed to parse int: strconv.ParseInt: parsing "two": invalid syntax
+	// MOUSE 4
+}
+
+func ExampleCSVRecords() {
+	r := strings.NewReader("CAT,3\nDOG,1\nMOUSE,4\n")
+	for record, err := range gloop.CSVRecords(r) {
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Println(record)
+	}
+	// Output:
+	// [CAT 3]
+	// [DOG 1]
+	// [MOUSE 4]
+}
+
+func ExampleWithCSVComma() {
+	r := strings.NewReader("CAT;3\nDOG;1\n")
+	for record, err := range gloop.CSVRecords(r, gloop.WithCSVComma(';')) {
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Println(record)
+	}
+	// Output:
+	// [CAT 3]
+	// [DOG 1]
+}
+
+func ExampleWithCSVComment() {
+	r := strings.NewReader("# animals\nCAT,3\nDOG,1\n")
+	for record, err := range gloop.CSVRecords(r, gloop.WithCSVComment('#')) {
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Println(record)
+	}
+	// Output:
+	// [CAT 3]
+	// [DOG 1]
+}
+
 func ExampleEnumerate() {
 	ch := make(chan int)
 	go func() {
@@ -205,6 +313,72 @@ func ExampleFilter2() {
 	// 4 4
 }
 
+func ExampleFilterErr() {
+	values := []string{"3", "4", "5", "CAT", "6"}
+	errs := make([]error, len(values))
+
+	for value, err := range gloop.FilterErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(errs)),
+		func(value string) (bool, error) {
+			n, err := strconv.Atoi(value)
+
+			return n > 3, err
+		},
+	) {
+		if err != nil {
+			fmt.Println(err)
+
+			continue
+		}
+
+		fmt.Println(value)
+	}
+	// Output:
+	// 4
+	// 5
+	// strconv.Atoi: parsing "CAT": invalid syntax
+}
+
+func ExampleJSONArray() {
+	type animal struct {
+		Name string `json:"name"`
+		Legs int    `json:"legs"`
+	}
+
+	r := strings.NewReader(`[{"name": "CAT", "legs": 4}, {"name": "CHICKEN", "legs": 2}]`)
+	for a, err := range gloop.JSONArray[animal](r) {
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Println(a.Name, a.Legs)
+	}
+	// Output:
+	// CAT 4
+	// CHICKEN 2
+}
+
+func ExampleJSONLines() {
+	type animal struct {
+		Name string `json:"name"`
+		Legs int    `json:"legs"`
+	}
+
+	r := strings.NewReader(`{"name": "CAT", "legs": 4}
+{"name": "CHICKEN", "legs": 2}
+`)
+	for a, err := range gloop.JSONLines[animal](r) {
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Println(a.Name, a.Legs)
+	}
+	// Output:
+	// CAT 4
+	// CHICKEN 2
+}
+
 func ExampleKeys() {
 	m := map[string]int{
 		"CAT":   3,
@@ -262,6 +436,52 @@ func ExampleKeyValue2() {
 	// MOUSE 4
 }
 
+func ExampleLines() {
+	r := strings.NewReader("CAT\nDOG\nMOUSE\n")
+	for line, err := range gloop.Lines(r) {
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Println(line)
+	}
+	// Output:
+	// CAT
+	// DOG
+	// MOUSE
+}
+
+func ExampleWithReaderBufferSize() {
+	r := strings.NewReader("CAT\nDOG\nMOUSE\n")
+	for line, err := range gloop.Lines(r, gloop.WithReaderBufferSize(8)) {
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Println(line)
+	}
+	// Output:
+	// CAT
+	// DOG
+	// MOUSE
+}
+
+func ExampleWithReaderMaxTokenSize() {
+	r := strings.NewReader("CAT\nCHICKEN\nDOG\n")
+	for line, err := range gloop.Lines(r, gloop.WithReaderBufferSize(4), gloop.WithReaderMaxTokenSize(4)) {
+		if err != nil {
+			fmt.Println(err)
+
+			break
+		}
+
+		fmt.Println(line)
+	}
+	// Output:
+	// CAT
+	// bufio.Scanner: token too long
+}
+
 func ExampleList() {
 	l := list.New()
 	l.PushBack(3)
@@ -315,6 +535,36 @@ func ExampleReverse2() {
 	// 0 3
 }
 
+func ExampleRunes() {
+	r := strings.NewReader("CAT")
+	for char, err := range gloop.Runes(r) {
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Println(string(char))
+	}
+	// Output:
+	// C
+	// A
+	// T
+}
+
+func ExampleScan() {
+	r := strings.NewReader("CAT DOG\nMOUSE")
+	for word, err := range gloop.Scan(r, bufio.ScanWords) {
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Println(word)
+	}
+	// Output:
+	// CAT
+	// DOG
+	// MOUSE
+}
+
 func ExampleSlice() {
 	values := []int{3, 1, 4}
 	for i := range gloop.Slice(values) {
@@ -408,6 +658,50 @@ func ExampleSortByRank2() {
 	// MOUSE CHICKEN
 }
 
+func ExampleParallelSort() {
+	values := []int{3, 1, 4, 1, 5, 9}
+	for i := range gloop.ParallelSort(gloop.Slice(values), true, gloop.WithParallelizeMaxThreads(2)) {
+		fmt.Println(i)
+	}
+	// Output:
+	// 1
+	// 1
+	// 3
+	// 4
+	// 5
+	// 9
+}
+
+func ExampleParallelSortByComparison() {
+	compareStringLens := func(s1, s2 string) bool {
+		return len(s1) < len(s2)
+	}
+
+	values := []string{"MOUSE", "CAT", "DOG"}
+	for s := range gloop.ParallelSortByComparison(gloop.Slice(values), compareStringLens, true) {
+		fmt.Println(s)
+	}
+	// Output:
+	// CAT
+	// DOG
+	// MOUSE
+}
+
+func ExampleParallelSortByRank() {
+	stringLen := func(s string) int {
+		return len(s)
+	}
+
+	values := []string{"MOUSE", "CAT", "DOG"}
+	for s := range gloop.ParallelSortByRank(gloop.Slice(values), stringLen, false) {
+		fmt.Println(s)
+	}
+	// Output:
+	// MOUSE
+	// CAT
+	// DOG
+}
+
 func ExampleString() {
 	for r := range gloop.String("CAT") {
 		fmt.Println(string(r))
@@ -418,6 +712,36 @@ func ExampleString() {
 	// T
 }
 
+func ExampleThrottle() {
+	timeElapsed := time.Now()
+
+	for value := range gloop.Throttle(gloop.Interval(0, 3, 1), 20) {
+		fmt.Println(value)
+	}
+
+	fmt.Println(time.Since(timeElapsed) >= time.Millisecond*100)
+	// Output:
+	// 0
+	// 1
+	// 2
+	// true
+}
+
+func ExampleWithThrottleBurst() {
+	timeElapsed := time.Now()
+
+	for value := range gloop.Throttle(gloop.Interval(0, 3, 1), 20, gloop.WithThrottleBurst(2)) {
+		fmt.Println(value)
+	}
+
+	fmt.Println(time.Since(timeElapsed) < time.Millisecond*100)
+	// Output:
+	// 0
+	// 1
+	// 2
+	// true
+}
+
 func ExampleUnique() {
 	values := []int{3, 1, 4, 1, 5, 9, 2, 6, 5}
 
@@ -449,6 +773,141 @@ func ExampleUnique2() {
 	// DOG 1
 }
 
+func ExampleWalk() {
+	fsys := fstest.MapFS{
+		"animals/cat.txt":   {Data: []byte("CAT")},
+		"animals/dog.txt":   {Data: []byte("DOG")},
+		"plants/tree.txt":   {Data: []byte("TREE")},
+		"plants/flower.txt": {Data: []byte("FLOWER")},
+	}
+
+	for path := range gloop.Walk(fsys, ".") {
+		fmt.Println(path)
+	}
+	// Output:
+	// .
+	// animals
+	// animals/cat.txt
+	// animals/dog.txt
+	// plants
+	// plants/flower.txt
+	// plants/tree.txt
+}
+
+func ExampleWithWalkMaxDepth() {
+	fsys := fstest.MapFS{
+		"animals/cat.txt":       {Data: []byte("CAT")},
+		"animals/birds/owl.txt": {Data: []byte("OWL")},
+	}
+
+	for path := range gloop.Walk(fsys, ".", gloop.WithWalkMaxDepth(2)) {
+		fmt.Println(path)
+	}
+	// Output:
+	// .
+	// animals
+	// animals/birds
+	// animals/cat.txt
+}
+
+func ExampleWithWalkInclude() {
+	fsys := fstest.MapFS{
+		"animals/cat.go":  {Data: []byte("CAT")},
+		"animals/dog.txt": {Data: []byte("DOG")},
+		"plants/tree.go":  {Data: []byte("TREE")},
+	}
+
+	for path := range gloop.Walk(fsys, ".", gloop.WithWalkInclude("*.go")) {
+		fmt.Println(path)
+	}
+	// Output:
+	// animals/cat.go
+	// plants/tree.go
+}
+
+func ExampleWithWalkExclude() {
+	fsys := fstest.MapFS{
+		"animals/cat.txt": {Data: []byte("CAT")},
+		"vendor/dog.txt":  {Data: []byte("DOG")},
+	}
+
+	for path := range gloop.Walk(fsys, ".", gloop.WithWalkExclude("vendor")) {
+		fmt.Println(path)
+	}
+	// Output:
+	// .
+	// animals
+	// animals/cat.txt
+}
+
+func ExampleWithWalkBreadthFirst() {
+	fsys := fstest.MapFS{
+		"animals/cat.txt": {Data: []byte("CAT")},
+		"plants/tree.txt": {Data: []byte("TREE")},
+	}
+
+	for path := range gloop.Walk(fsys, ".", gloop.WithWalkBreadthFirst(true)) {
+		fmt.Println(path)
+	}
+	// Output:
+	// .
+	// animals
+	// plants
+	// animals/cat.txt
+	// plants/tree.txt
+}
+
+func ExampleWithWalkControl() {
+	fsys := fstest.MapFS{
+		"animals/cat.txt": {Data: []byte("CAT")},
+		"plants/tree.txt": {Data: []byte("TREE")},
+	}
+
+	var control gloop.WalkControl
+
+	for path, entry := range gloop.Walk(fsys, ".", gloop.WithWalkControl(&control)) {
+		fmt.Println(path)
+
+		if entry.Name() == "animals" {
+			control.SkipDir()
+		}
+	}
+
+	if err := control.Err(); err != nil {
+		panic(err)
+	}
+	// Output:
+	// .
+	// animals
+	// plants
+	// plants/tree.txt
+}
+
+func ExampleXMLElements() {
+	type item struct {
+		Title string `xml:"title"`
+	}
+
+	r := strings.NewReader(`<rss>
+	<channel>
+		<title>Animals</title>
+		<item><title>CAT</title></item>
+		<item><title>DOG</title></item>
+	</channel>
+</rss>`)
+
+	for i, err := range gloop.XMLElements[item](r, "channel/item") {
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Println(i.Title)
+	}
+	// Output:
+	// CAT
+	// DOG
+}
+
 func ExampleZip() {
 	values1 := []string{"CAT", "DOG", "MOUSE"}
 	values2 := []int{3, 1, 4}
@@ -793,32 +1252,192 @@ func ExampleCombinations2() {
 	// [DOG MOUSE] [1 4]
 }
 
-func ExamplePermutations() {
-	s := "CAT"
-	for seq := range gloop.Permutations(gloop.String(s), 2) {
-		perm := gloop.ToString(seq)
-		fmt.Println(perm)
+func ExampleEventTimeWindow() {
+	type event struct {
+		name string
+		time time.Time
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []event{
+		{"CAT", start},
+		{"DOG", start.Add(time.Second * 70)},
+		{"MOUSE", start.Add(time.Second * 50)},
+		{"CHICKEN", start.Add(time.Second * 150)},
+	}
+
+	for seq := range gloop.EventTimeWindow(
+		gloop.Slice(events),
+		time.Minute,
+		func(e event) time.Time {
+			return e.time
+		},
+		gloop.WithEventTimeWindowAllowedLateness[event](time.Second*30),
+	) {
+		names := gloop.Transform(seq, func(e event) string {
+			return e.name
+		})
+		fmt.Println(gloop.ToSlice(names))
 	}
 	// Output:
-	// CA
-	// CT
-	// AC
-	// AT
-	// TC
-	// TA
+	// [CAT MOUSE]
+	// [DOG]
+	// [CHICKEN]
 }
 
-func ExamplePermutations2() {
-	m := map[string]int{
-		"CAT":   3,
-		"DOG":   1,
-		"MOUSE": 4,
+func ExampleWithEventTimeWindowStep() {
+	type event struct {
+		name string
+		time time.Time
 	}
-	for seq := range gloop.Permutations2(gloop.Map(m), 2) {
-		permKeys, permValues := gloop.ToSlice2(seq)
-		fmt.Println(permKeys, permValues)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []event{
+		{"CAT", start},
+		{"DOG", start.Add(time.Second * 40)},
+		{"MOUSE", start.Add(time.Second * 70)},
 	}
-	// Output:
+
+	for seq := range gloop.EventTimeWindow(
+		gloop.Slice(events),
+		time.Minute,
+		func(e event) time.Time {
+			return e.time
+		},
+		gloop.WithEventTimeWindowStep[event](time.Second*30),
+	) {
+		names := gloop.Transform(seq, func(e event) string {
+			return e.name
+		})
+		fmt.Println(gloop.ToSlice(names))
+	}
+	// Output:
+	// [CAT]
+	// [CAT DOG]
+	// [DOG MOUSE]
+	// [MOUSE]
+}
+
+func ExampleWithEventTimeWindowAllowedLateness() {
+	type event struct {
+		name string
+		time time.Time
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []event{
+		{"CAT", start.Add(time.Second * 70)},
+		{"DOG", start.Add(time.Second * 10)},
+	}
+
+	for seq := range gloop.EventTimeWindow(
+		gloop.Slice(events),
+		time.Minute,
+		func(e event) time.Time {
+			return e.time
+		},
+		gloop.WithEventTimeWindowAllowedLateness[event](time.Minute),
+	) {
+		names := gloop.Transform(seq, func(e event) string {
+			return e.name
+		})
+		fmt.Println(gloop.ToSlice(names))
+	}
+	// Output:
+	// [DOG]
+	// [CAT]
+}
+
+func ExampleWithEventTimeWindowLate() {
+	type event struct {
+		name string
+		time time.Time
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []event{
+		{"CAT", start.Add(time.Second * 70)},
+		{"DOG", start.Add(time.Second * 10)},
+	}
+
+	for seq := range gloop.EventTimeWindow(
+		gloop.Slice(events),
+		time.Minute,
+		func(e event) time.Time {
+			return e.time
+		},
+		gloop.WithEventTimeWindowLate(func(e event) {
+			fmt.Println("late", e.name)
+		}),
+	) {
+		names := gloop.Transform(seq, func(e event) string {
+			return e.name
+		})
+		fmt.Println(gloop.ToSlice(names))
+	}
+	// Output:
+	// late DOG
+	// [CAT]
+}
+
+func ExampleEventTimeWindow2() {
+	type reading struct {
+		value int
+		time  time.Time
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	seq := func(yield func(string, reading) bool) {
+		_ = yield("CAT", reading{3, start}) &&
+			yield("DOG", reading{1, start.Add(time.Second * 10)}) &&
+			yield("CAT", reading{4, start.Add(time.Second * 20)}) &&
+			yield("DOG", reading{1, start.Add(time.Second * 70)})
+	}
+
+	for key, window := range gloop.EventTimeWindow2(
+		seq,
+		time.Minute,
+		func(_ string, r reading) time.Time {
+			return r.time
+		},
+	) {
+		values := gloop.Transform(window, func(r reading) int {
+			return r.value
+		})
+		fmt.Println(key, gloop.ToSlice(values))
+	}
+	// Output:
+	// CAT [3 4]
+	// DOG [1]
+	// DOG [1]
+}
+
+func ExamplePermutations() {
+	s := "CAT"
+	for seq := range gloop.Permutations(gloop.String(s), 2) {
+		perm := gloop.ToString(seq)
+		fmt.Println(perm)
+	}
+	// Output:
+	// CA
+	// CT
+	// AC
+	// AT
+	// TC
+	// TA
+}
+
+func ExamplePermutations2() {
+	m := map[string]int{
+		"CAT":   3,
+		"DOG":   1,
+		"MOUSE": 4,
+	}
+	for seq := range gloop.Permutations2(gloop.Map(m), 2) {
+		permKeys, permValues := gloop.ToSlice2(seq)
+		fmt.Println(permKeys, permValues)
+	}
+	// Output:
 	// [CAT DOG] [3 1]
 	// [CAT MOUSE] [3 4]
 	// [DOG CAT] [1 3]
@@ -827,6 +1446,149 @@ func ExamplePermutations2() {
 	// [MOUSE DOG] [4 1]
 }
 
+func ExampleSessionWindow() {
+	type event struct {
+		name string
+		time time.Time
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []event{
+		{"CAT", start},
+		{"DOG", start.Add(time.Second * 20)},
+		{"MOUSE", start.Add(time.Minute * 2)},
+		{"CHICKEN", start.Add(time.Minute * 3)},
+	}
+
+	for seq := range gloop.SessionWindow(
+		gloop.Slice(events),
+		time.Minute,
+		gloop.WithTimeWindowTimestamp(func(e event) time.Time {
+			return e.time
+		}),
+	) {
+		names := gloop.Transform(seq, func(e event) string {
+			return e.name
+		})
+		fmt.Println(gloop.ToSlice(names))
+	}
+	// Output:
+	// [CAT DOG]
+	// [MOUSE]
+	// [CHICKEN]
+}
+
+func ExampleSlidingTimeWindow() {
+	type event struct {
+		name string
+		time time.Time
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []event{
+		{"CAT", start},
+		{"DOG", start.Add(time.Second * 40)},
+		{"MOUSE", start.Add(time.Second * 70)},
+	}
+
+	for seq := range gloop.SlidingTimeWindow(
+		gloop.Slice(events),
+		time.Minute,
+		time.Second*30,
+		gloop.WithTimeWindowTimestamp(func(e event) time.Time {
+			return e.time
+		}),
+	) {
+		names := gloop.Transform(seq, func(e event) string {
+			return e.name
+		})
+		fmt.Println(gloop.ToSlice(names))
+	}
+	// Output:
+	// [CAT]
+	// [CAT DOG]
+	// [DOG MOUSE]
+	// [MOUSE]
+}
+
+func ExampleTumblingTimeWindow() {
+	type event struct {
+		name string
+		time time.Time
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []event{
+		{"CAT", start},
+		{"DOG", start.Add(time.Second * 40)},
+		{"MOUSE", start.Add(time.Second * 70)},
+		{"CHICKEN", start.Add(time.Minute * 5)},
+	}
+
+	for seq := range gloop.TumblingTimeWindow(
+		gloop.Slice(events),
+		time.Minute,
+		gloop.WithTimeWindowTimestamp(func(e event) time.Time {
+			return e.time
+		}),
+	) {
+		names := gloop.Transform(seq, func(e event) string {
+			return e.name
+		})
+		fmt.Println(gloop.ToSlice(names))
+	}
+	// Output:
+	// [CAT DOG]
+	// [MOUSE]
+	// [CHICKEN]
+}
+
+func ExampleWithTimeWindowTimestamp() {
+	type event struct {
+		name string
+		time time.Time
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []event{
+		{"CAT", start},
+		{"DOG", start.Add(time.Minute * 2)},
+	}
+
+	for seq := range gloop.SessionWindow(
+		gloop.Slice(events),
+		time.Minute,
+		gloop.WithTimeWindowTimestamp(func(e event) time.Time {
+			return e.time
+		}),
+	) {
+		for e := range seq {
+			fmt.Println(e.name)
+		}
+	}
+	// Output:
+	// CAT
+	// DOG
+}
+
+func ExampleWithTimeWindowClock() {
+	ch := make(chan string, 3)
+	ch <- "CAT"
+	ch <- "DOG"
+	ch <- "MOUSE"
+	close(ch)
+
+	for seq := range gloop.SessionWindow(
+		gloop.Channel(ch),
+		time.Hour,
+		gloop.WithTimeWindowClock[string](gloop.SystemClock{}),
+	) {
+		fmt.Println(gloop.ToSlice(seq))
+	}
+	// Output:
+	// [CAT DOG MOUSE]
+}
+
 func ExampleWindow() {
 	values := []int{3, 1, 4, 1, 5, 9}
 	for seq := range gloop.Window(gloop.Slice(values), 3) {
@@ -853,6 +1615,192 @@ func ExampleWindow2() {
 	// [3 4 5] [CHICKEN BUNNY BEAR]
 }
 
+func ExampleWithWindowStep() {
+	values := []int{3, 1, 4, 1, 5, 9}
+	for seq := range gloop.Window(
+		gloop.Slice(values),
+		2,
+		gloop.WithWindowStep[int](2),
+	) {
+		window := gloop.ToSlice(seq)
+		fmt.Println(window)
+	}
+	// Output:
+	// [3 1]
+	// [4 1]
+	// [5 9]
+}
+
+func ExampleWithWindowPartial() {
+	values := []int{3, 1, 4, 1, 5}
+	for seq := range gloop.Window(
+		gloop.Slice(values),
+		2,
+		gloop.WithWindowStep[int](2),
+		gloop.WithWindowPartial[int](true),
+	) {
+		window := gloop.ToSlice(seq)
+		fmt.Println(window)
+	}
+	// Output:
+	// [3 1]
+	// [4 1]
+	// [5]
+}
+
+func ExampleWithWindowExpanding() {
+	values := []int{3, 1, 4, 1}
+	for seq := range gloop.Window(
+		gloop.Slice(values),
+		3,
+		gloop.WithWindowExpanding[int](true),
+	) {
+		window := gloop.ToSlice(seq)
+		fmt.Println(window)
+	}
+	// Output:
+	// [3]
+	// [3 1]
+	// [3 1 4]
+	// [1 4 1]
+}
+
+func ExampleWithWindowPadded() {
+	values := []int{3, 1, 4, 1, 5}
+	for seq := range gloop.Window(
+		gloop.Slice(values),
+		2,
+		gloop.WithWindowStep[int](2),
+		gloop.WithWindowPartial[int](true),
+		gloop.WithWindowPadded[int](true),
+	) {
+		window := gloop.ToSlice(seq)
+		fmt.Println(window)
+	}
+	// Output:
+	// [3 1]
+	// [4 1]
+	// [5 0]
+}
+
+func ExampleWithWindowPadValue() {
+	values := []int{3, 1, 4}
+	for seq := range gloop.Window(
+		gloop.Slice(values),
+		3,
+		gloop.WithWindowExpanding[int](true),
+		gloop.WithWindowPadded[int](true),
+		gloop.WithWindowPadValue(42),
+	) {
+		window := gloop.ToSlice(seq)
+		fmt.Println(window)
+	}
+	// Output:
+	// [42 42 3]
+	// [42 3 1]
+	// [3 1 4]
+}
+
+func ExampleWithWindow2Step() {
+	values := []string{"CAT", "DOG", "MOUSE", "CHICKEN", "BUNNY", "BEAR"}
+	for seq := range gloop.Window2(
+		gloop.Enumerate(gloop.Slice(values)),
+		3,
+		gloop.WithWindow2Step[int, string](3),
+	) {
+		windowKeys, windowValues := gloop.ToSlice2(seq)
+		fmt.Println(windowKeys, windowValues)
+	}
+	// Output:
+	// [0 1 2] [CAT DOG MOUSE]
+	// [3 4 5] [CHICKEN BUNNY BEAR]
+}
+
+func ExampleWithWindow2Partial() {
+	values := []string{"CAT", "DOG", "MOUSE", "CHICKEN"}
+	for seq := range gloop.Window2(
+		gloop.Enumerate(gloop.Slice(values)),
+		3,
+		gloop.WithWindow2Step[int, string](3),
+		gloop.WithWindow2Partial[int, string](true),
+	) {
+		windowKeys, windowValues := gloop.ToSlice2(seq)
+		fmt.Println(windowKeys, windowValues)
+	}
+	// Output:
+	// [0 1 2] [CAT DOG MOUSE]
+	// [3] [CHICKEN]
+}
+
+func ExampleWithWindow2Expanding() {
+	values := []string{"CAT", "DOG", "MOUSE"}
+	for seq := range gloop.Window2(
+		gloop.Enumerate(gloop.Slice(values)),
+		2,
+		gloop.WithWindow2Expanding[int, string](true),
+	) {
+		windowKeys, windowValues := gloop.ToSlice2(seq)
+		fmt.Println(windowKeys, windowValues)
+	}
+	// Output:
+	// [0] [CAT]
+	// [0 1] [CAT DOG]
+	// [1 2] [DOG MOUSE]
+}
+
+func ExampleWithWindow2Padded() {
+	values := []string{"CAT", "DOG", "MOUSE"}
+	for seq := range gloop.Window2(
+		gloop.Enumerate(gloop.Slice(values)),
+		2,
+		gloop.WithWindow2Step[int, string](2),
+		gloop.WithWindow2Partial[int, string](true),
+		gloop.WithWindow2Padded[int, string](true),
+	) {
+		windowKeys, windowValues := gloop.ToSlice2(seq)
+		fmt.Printf("%v %q\n", windowKeys, windowValues)
+	}
+	// Output:
+	// [0 1] ["CAT" "DOG"]
+	// [2 0] ["MOUSE" ""]
+}
+
+func ExampleWithWindow2PadKey() {
+	values := []string{"CAT", "DOG", "MOUSE"}
+	for seq := range gloop.Window2(
+		gloop.Enumerate(gloop.Slice(values)),
+		2,
+		gloop.WithWindow2Step[int, string](2),
+		gloop.WithWindow2Partial[int, string](true),
+		gloop.WithWindow2Padded[int, string](true),
+		gloop.WithWindow2PadKey[int, string](-1),
+	) {
+		windowKeys, windowValues := gloop.ToSlice2(seq)
+		fmt.Printf("%v %q\n", windowKeys, windowValues)
+	}
+	// Output:
+	// [0 1] ["CAT" "DOG"]
+	// [2 -1] ["MOUSE" ""]
+}
+
+func ExampleWithWindow2PadValue() {
+	values := []string{"CAT", "DOG", "MOUSE"}
+	for seq := range gloop.Window2(
+		gloop.Enumerate(gloop.Slice(values)),
+		2,
+		gloop.WithWindow2Step[int, string](2),
+		gloop.WithWindow2Partial[int, string](true),
+		gloop.WithWindow2Padded[int, string](true),
+		gloop.WithWindow2PadValue[int]("BEAR"),
+	) {
+		windowKeys, windowValues := gloop.ToSlice2(seq)
+		fmt.Println(windowKeys, windowValues)
+	}
+	// Output:
+	// [0 1] [CAT DOG]
+	// [2 0] [MOUSE BEAR]
+}
+
 func ExampleZipN() {
 	seq1 := gloop.Slice([]string{"CAT", "DOG"})
 	seq2 := gloop.Slice([]string{"MOUSE", "CHICKEN"})
@@ -1150,6 +2098,62 @@ func ExampleFold2() {
 	// 44
 }
 
+func ExampleFoldErr() {
+	values := []string{"3", "CAT", "5"}
+	errs := make([]error, len(values))
+
+	sum, err := gloop.FoldErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(errs)),
+		func(acc int, value string) (int, error) {
+			n, err := strconv.Atoi(value)
+
+			return acc + n, err
+		},
+	)
+
+	fmt.Println(sum, err)
+	// Output:
+	// 3 strconv.Atoi: parsing "CAT": invalid syntax
+}
+
+func ExampleWithFoldErrInitialValue() {
+	values := []string{"3", "4", "5"}
+	errs := make([]error, len(values))
+
+	sum, err := gloop.FoldErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(errs)),
+		func(acc int, value string) (int, error) {
+			n, err := strconv.Atoi(value)
+
+			return acc + n, err
+		},
+		gloop.WithFoldErrInitialValue(10),
+	)
+
+	fmt.Println(sum, err)
+	// Output:
+	// 22 <nil>
+}
+
+func ExampleWithFoldErrCollect() {
+	values := []string{"3", "CAT", "5"}
+	errs := make([]error, len(values))
+
+	sum, err := gloop.FoldErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(errs)),
+		func(acc int, value string) (int, error) {
+			n, err := strconv.Atoi(value)
+
+			return acc + n, err
+		},
+		gloop.WithFoldErrCollect[int](true),
+	)
+
+	fmt.Println(sum, err)
+	// Output:
+	// 8 strconv.Atoi: parsing "CAT": invalid syntax
+}
+
 func ExampleMax() {
 	values := []int{3, 1, 4, 2}
 	maxValue := gloop.Max(gloop.Slice(values))
@@ -1290,6 +2294,46 @@ func ExampleMinByRank2() {
 	// 3 1
 }
 
+func ExampleParallelFold() {
+	add := func(acc int, value int) int {
+		return acc + value
+	}
+
+	sum, err := gloop.ParallelFold(gloop.Interval(1, 101, 1), 0, add, add)
+	fmt.Println(sum, err)
+	// Output:
+	// 5050 <nil>
+}
+
+func ExampleWithParallelizeChunkSize() {
+	concat := func(acc string, value string) string {
+		return acc + value
+	}
+
+	s, err := gloop.ParallelFold(
+		gloop.Slice([]string{"C", "A", "T", "D", "O", "G"}),
+		"",
+		concat,
+		concat,
+		gloop.WithParallelizeChunkSize(2),
+	)
+	fmt.Println(s, err)
+	// Output:
+	// CATDOG <nil>
+}
+
+func ExampleParallelFoldSlice() {
+	values := []float64{1.5, 2.5, 3.5, 4.5}
+	add := func(acc float64, value float64) float64 {
+		return acc + value
+	}
+
+	sum, err := gloop.ParallelFoldSlice(values, 0, add, add)
+	fmt.Println(sum, err)
+	// Output:
+	// 12 <nil>
+}
+
 func ExampleProduct() {
 	values := []int{3, 1, 4}
 	prod := gloop.Product(gloop.Slice(values))
@@ -1357,14 +2401,46 @@ func ExampleTransform2() {
 	// MOUSECHICKEN
 }
 
-func ExampleValues() {
-	m := map[string]int{
-		"CAT":   3,
-		"DOG":   1,
-		"MOUSE": 4,
-	}
+func ExampleTransformErr() {
+	values := []string{"3", "CAT", "5"}
+	errs := make([]error, len(values))
 
-	for value := range gloop.Values(gloop.Map(m)) {
+	for value, err := range gloop.TransformErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(errs)),
+		strconv.Atoi,
+	) {
+		fmt.Println(value, err)
+	}
+	// Output:
+	// 3 <nil>
+	// 0 strconv.Atoi: parsing "CAT": invalid syntax
+}
+
+func ExampleWithErrorCollect() {
+	values := []string{"3", "CAT", "5"}
+	errs := make([]error, len(values))
+
+	for value, err := range gloop.TransformErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(errs)),
+		strconv.Atoi,
+		gloop.WithErrorCollect(true),
+	) {
+		fmt.Println(value, err)
+	}
+	// Output:
+	// 3 <nil>
+	// 0 strconv.Atoi: parsing "CAT": invalid syntax
+	// 5 <nil>
+}
+
+func ExampleValues() {
+	m := map[string]int{
+		"CAT":   3,
+		"DOG":   1,
+		"MOUSE": 4,
+	}
+
+	for value := range gloop.Values(gloop.Map(m)) {
 		fmt.Println(value)
 	}
 	// Output:
@@ -1381,6 +2457,91 @@ func ExampleSum() {
 	// 8
 }
 
+func ExampleToCSV() {
+	type animal struct {
+		Name string `csv:"name"`
+		Legs int    `csv:"legs"`
+	}
+
+	animals := []animal{
+		{"CAT", 4},
+		{"CHICKEN", 2},
+	}
+
+	var sb strings.Builder
+
+	err := gloop.ToCSV(&sb, gloop.Slice(animals))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(sb.String())
+	// Output:
+	// name,legs
+	// CAT,4
+	// CHICKEN,2
+}
+
+func ExampleToCSV2() {
+	values := []string{"CAT", "DOG", "MOUSE"}
+
+	var sb strings.Builder
+
+	err := gloop.ToCSV2(&sb, gloop.Enumerate(gloop.Slice(values)))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(sb.String())
+	// Output:
+	// 0,CAT
+	// 1,DOG
+	// 2,MOUSE
+}
+
+func ExampleToCSVRecords() {
+	records := [][]string{
+		{"CAT", "3"},
+		{"DOG", "1"},
+	}
+
+	var sb strings.Builder
+
+	err := gloop.ToCSVRecords(&sb, gloop.Slice(records))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(sb.String())
+	// Output:
+	// CAT,3
+	// DOG,1
+}
+
+func ExampleToJSONLines() {
+	type animal struct {
+		Name string `json:"name"`
+		Legs int    `json:"legs"`
+	}
+
+	animals := []animal{
+		{"CAT", 4},
+		{"CHICKEN", 2},
+	}
+
+	var sb strings.Builder
+
+	err := gloop.ToJSONLines(&sb, gloop.Slice(animals))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(sb.String())
+	// Output:
+	// {"name":"CAT","legs":4}
+	// {"name":"CHICKEN","legs":2}
+}
+
 func ExampleToList() {
 	seq := func(yield func(int) bool) {
 		yield(3)
@@ -1447,6 +2608,27 @@ func ExampleToSlice2() {
 	// [CAT DOG MOUSE] [3 1 4]
 }
 
+func ExampleToSliceErr() {
+	values := []string{"3", "CAT", "5", "DOG"}
+	errs := make([]error, len(values))
+
+	numbers, err := gloop.ToSliceErr(
+		gloop.TransformErr(
+			gloop.Zip(gloop.Slice(values), gloop.Slice(errs)),
+			strconv.Atoi,
+			gloop.WithErrorCollect(true),
+		),
+		gloop.WithErrorCollect(true),
+	)
+
+	fmt.Println(numbers)
+	fmt.Println(err)
+	// Output:
+	// [3 5]
+	// strconv.Atoi: parsing "CAT": invalid syntax
+	// strconv.Atoi: parsing "DOG": invalid syntax
+}
+
 func ExampleToString() {
 	seq := func(yield func(rune) bool) {
 		yield('C')
@@ -1477,6 +2659,55 @@ func ExampleDeferLoop() {
 	// defer loop 4
 }
 
+func ExampleMicroBatch() {
+	err := gloop.MicroBatch(gloop.Interval(0, 7, 1), 3, func(_ context.Context, batch []int) error {
+		fmt.Println(batch)
+
+		return nil
+	})
+	fmt.Println(err)
+	// Output:
+	// [0 1 2]
+	// [3 4 5]
+	// [6]
+	// <nil>
+}
+
+func ExampleWithMicroBatchMaxLatency() {
+	ch := make(chan int)
+
+	go func() {
+		ch <- 1
+		ch <- 2
+		time.Sleep(100 * time.Millisecond)
+		ch <- 3
+		close(ch)
+	}()
+
+	_ = gloop.MicroBatch(gloop.Channel(ch), 10, func(_ context.Context, batch []int) error {
+		fmt.Println(batch)
+
+		return nil
+	}, gloop.WithMicroBatchMaxLatency(50*time.Millisecond))
+	// Output:
+	// [1 2]
+	// [3]
+}
+
+func ExampleWithMicroBatchMaxThreads() {
+	var sum atomic.Int64
+
+	_ = gloop.MicroBatch(gloop.Interval(1, 11, 1), 2, func(_ context.Context, batch []int) error {
+		sum.Add(int64(gloop.Sum(gloop.Slice(batch))))
+
+		return nil
+	}, gloop.WithMicroBatchMaxThreads(4))
+
+	fmt.Println(sum.Load())
+	// Output:
+	// 55
+}
+
 func ExampleParallelize() {
 	printlnWithDelay := func(s string) {
 		time.Sleep(time.Second)
@@ -1566,3 +2797,530 @@ func ExampleParallelize2() {
 	// DOG 1
 	// Time Elapsed 1.00058975s
 }
+
+func ExampleWithParallelizeSerializeKeys() {
+	events := func(yield func(string, int) bool) {
+		for i := range 3 {
+			if !yield("alice", i) || !yield("bob", i*10) {
+				return
+			}
+		}
+	}
+
+	var mu sync.Mutex
+
+	transactions := make(map[string][]int)
+
+	gloop.Parallelize2(events, func(account string, amount int) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		transactions[account] = append(transactions[account], amount)
+	}, gloop.WithParallelizeSerializeKeys(true))
+
+	fmt.Println(transactions["alice"])
+	fmt.Println(transactions["bob"])
+	// Output:
+	// [0 1 2]
+	// [0 10 20]
+}
+
+func ExampleParallelizeContext() {
+	ch := make(chan string)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	processed := make(chan string)
+	done := make(chan struct{})
+
+	go func() {
+		gloop.ParallelizeContext(gloop.Channel(ch), func(_ context.Context, v string) {
+			processed <- v
+		}, gloop.WithParallelizeContext(ctx))
+		close(done)
+	}()
+
+	ch <- "CAT"
+	fmt.Println(<-processed)
+
+	ch <- "DOG"
+	fmt.Println(<-processed)
+
+	cancel()
+	<-done
+
+	fmt.Println("stopped")
+	// Output:
+	// CAT
+	// DOG
+	// stopped
+}
+
+func ExampleParallelizeContext2() {
+	m := map[string]int{
+		"CAT":   3,
+		"DOG":   1,
+		"MOUSE": 4,
+	}
+
+	var sum atomic.Int64
+
+	gloop.ParallelizeContext2(gloop.Map(m), func(ctx context.Context, _ string, v int) {
+		if ctx.Err() == nil {
+			sum.Add(int64(v))
+		}
+	})
+
+	fmt.Println(sum.Load())
+	// Output:
+	// 8
+}
+
+func ExampleWithParallelizeTaskTimeout() {
+	durations := []time.Duration{time.Millisecond * 10, time.Second}
+
+	gloop.ParallelizeContext2(gloop.Enumerate(gloop.Slice(durations)), func(ctx context.Context, i int, d time.Duration) {
+		select {
+		case <-time.After(d):
+			fmt.Println(i, "finished")
+		case <-ctx.Done():
+			fmt.Println(i, ctx.Err())
+		}
+	},
+		gloop.WithParallelizeTaskTimeout(time.Millisecond*100),
+		gloop.WithParallelizeMaxThreads(1),
+	)
+	// Output:
+	// 0 finished
+	// 1 context deadline exceeded
+}
+
+func ExampleWithParallelizeWeight() {
+	jobs := []int64{3, 1, 2, 4, 2, 1}
+
+	var mu sync.Mutex
+	inFlight := int64(0)
+	maxInFlight := int64(0)
+
+	gloop.Parallelize(gloop.Slice(jobs), func(job int64) {
+		mu.Lock()
+		inFlight += job
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond * 10)
+
+		mu.Lock()
+		inFlight -= job
+		mu.Unlock()
+	}, gloop.WithParallelizeWeight(5, func(job int64) int64 {
+		return job
+	}))
+
+	fmt.Println(maxInFlight <= 5)
+	// Output:
+	// true
+}
+
+func ExampleWithParallelizePriority() {
+	jobs := []string{"setup", "low", "high", "medium"}
+	priorities := map[string]int{
+		"setup":  4,
+		"low":    1,
+		"high":   3,
+		"medium": 2,
+	}
+
+	pulled := make(chan struct{})
+	seq := func(yield func(string) bool) {
+		defer close(pulled)
+
+		for _, job := range jobs {
+			if !yield(job) {
+				return
+			}
+		}
+	}
+
+	gloop.Parallelize(seq, func(job string) {
+		if job == "setup" {
+			<-pulled
+		}
+
+		fmt.Println(job)
+	},
+		gloop.WithParallelizeMaxThreads(1),
+		gloop.WithParallelizePriority(func(job string) int {
+			return priorities[job]
+		}, len(jobs)),
+	)
+	// Output:
+	// setup
+	// high
+	// medium
+	// low
+}
+
+func ExampleParallelizeErr() {
+	values := []string{"3", "CAT", "5"}
+
+	err := gloop.ParallelizeErr(gloop.Slice(values), func(_ context.Context, value string) error {
+		_, err := strconv.Atoi(value)
+
+		return err
+	})
+
+	fmt.Println(err)
+	// Output:
+	// strconv.Atoi: parsing "CAT": invalid syntax
+}
+
+func ExampleWithParallelizeJoinErrors() {
+	values := []string{"3", "CAT", "5", "DOG"}
+
+	err := gloop.ParallelizeErr(gloop.Slice(values), func(_ context.Context, value string) error {
+		_, err := strconv.Atoi(value)
+
+		return err
+	}, gloop.WithParallelizeJoinErrors(true), gloop.WithParallelizeMaxThreads(1))
+
+	fmt.Println(err)
+	// Output:
+	// strconv.Atoi: parsing "CAT": invalid syntax
+	// strconv.Atoi: parsing "DOG": invalid syntax
+}
+
+func ExampleWithParallelizeRecoverPanics() {
+	values := []string{"CAT", "DOG"}
+
+	err := gloop.ParallelizeErr(gloop.Slice(values), func(_ context.Context, value string) error {
+		if value == "DOG" {
+			panic("WOOF")
+		}
+
+		return nil
+	}, gloop.WithParallelizeRecoverPanics(true))
+
+	var panicErr *gloop.PanicError
+	if errors.As(err, &panicErr) {
+		fmt.Println(panicErr.Value)
+	}
+	// Output:
+	// WOOF
+}
+
+func ExampleWithParallelizePanicHandler() {
+	values := []string{"CAT", "DOG"}
+
+	gloop.Parallelize(gloop.Slice(values), func(value string) {
+		if value == "DOG" {
+			panic("WOOF")
+		}
+	}, gloop.WithParallelizePanicHandler(func(err *gloop.PanicError) {
+		fmt.Println(err)
+	}))
+	// Output:
+	// recovered panic: WOOF
+}
+
+func ExampleWithParallelizeRepanic() {
+	values := []string{"CAT", "DOG"}
+
+	defer func() {
+		fmt.Println(recover())
+	}()
+
+	gloop.Parallelize(gloop.Slice(values), func(value string) {
+		if value == "DOG" {
+			panic("WOOF")
+		}
+	}, gloop.WithParallelizeRepanic(true))
+	// Output:
+	// recovered panic: WOOF
+}
+
+func ExampleWithParallelizeRateLimit() {
+	timeElapsed := time.Now()
+
+	gloop.Parallelize(gloop.Interval(0, 3, 1), func(_ int) {}, gloop.WithParallelizeRateLimit(20, 1))
+
+	fmt.Println(time.Since(timeElapsed) >= time.Millisecond*100)
+	// Output:
+	// true
+}
+
+func ExampleWithParallelizeRetry() {
+	var attempts atomic.Int64
+
+	report := &gloop.RetryReport{}
+
+	err := gloop.ParallelizeErr(gloop.Slice([]string{"CAT"}), func(_ context.Context, _ string) error {
+		if attempts.Add(1) < 3 {
+			return errors.New("TRY AGAIN")
+		}
+
+		return nil
+	}, gloop.WithParallelizeRetry(gloop.RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: time.Millisecond,
+		Report:         report,
+	}))
+
+	fmt.Println(err)
+
+	for _, result := range report.Results() {
+		fmt.Println(result.Index, result.Attempts, result.Err)
+	}
+	// Output:
+	// <nil>
+	// 0 3 <nil>
+}
+
+func ExampleParallelizeErr2() {
+	m := map[string]string{
+		"CAT": "3",
+		"DOG": "ONE",
+	}
+
+	err := gloop.ParallelizeErr2(gloop.Map(m), func(_ context.Context, key string, value string) error {
+		_, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+
+		return nil
+	})
+
+	fmt.Println(err)
+	// Output:
+	// DOG: strconv.Atoi: parsing "ONE": invalid syntax
+}
+
+func ExampleParallelTransform() {
+	square := func(value int) int {
+		time.Sleep(time.Duration(5-value) * 10 * time.Millisecond)
+
+		return value * value
+	}
+
+	for value := range gloop.ParallelTransform(gloop.Interval(1, 5, 1), square) {
+		fmt.Println(value)
+	}
+	// Output:
+	// 1
+	// 4
+	// 9
+	// 16
+}
+
+func ExampleWithParallelizeUnordered() {
+	square := func(value int) int {
+		time.Sleep(time.Duration(5-value) * 10 * time.Millisecond)
+
+		return value * value
+	}
+
+	for value := range gloop.ParallelTransform(
+		gloop.Interval(1, 5, 1),
+		square,
+		gloop.WithParallelizeUnordered(true),
+		gloop.WithParallelizeMaxThreads(4),
+	) {
+		fmt.Println(value)
+	}
+	// Output:
+	// 16
+	// 9
+	// 4
+	// 1
+}
+
+func ExampleParallelFor() {
+	values := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	sums := make([]int, 4)
+
+	err := gloop.ParallelFor(0, len(values), func(_ context.Context, low int, high int) error {
+		sums[low/2] = gloop.Sum(gloop.Slice(values[low:high]))
+
+		return nil
+	}, gloop.WithParallelizeChunkSize(2))
+
+	fmt.Println(sums, err)
+	// Output:
+	// [4 5 14 8] <nil>
+}
+
+func ExampleParallelForEach() {
+	squares := make([]int, 5)
+
+	err := gloop.ParallelForEach(0, len(squares), func(_ context.Context, i int) error {
+		squares[i] = i * i
+
+		return nil
+	})
+
+	fmt.Println(squares, err)
+	// Output:
+	// [0 1 4 9 16] <nil>
+}
+
+func ExampleNewPipeline() {
+	p := gloop.NewPipeline(gloop.Interval(1, 5, 1))
+	squared := gloop.Stage(p, func(value int) int {
+		return value * value
+	})
+	formatted := gloop.Stage(squared, func(value int) string {
+		return "#" + strconv.Itoa(value)
+	})
+
+	for value := range formatted.Seq() {
+		fmt.Println(value)
+	}
+	// Output:
+	// #1
+	// #4
+	// #9
+	// #16
+}
+
+func ExampleWithPipelineContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := gloop.NewPipeline(gloop.Interval(1, 100, 1), gloop.WithPipelineContext(ctx))
+	stage := gloop.Stage(p, func(value int) int {
+		return value * value
+	})
+
+	for value := range stage.Seq() {
+		fmt.Println(value)
+
+		if value == 9 {
+			cancel()
+		}
+	}
+	// Output:
+	// 1
+	// 4
+	// 9
+}
+
+func ExampleStage() {
+	p := gloop.NewPipeline(gloop.Interval(1, 5, 1))
+	stage := gloop.Stage(p, func(value int) int {
+		return value * value
+	})
+
+	for value := range stage.Seq() {
+		fmt.Println(value)
+	}
+	// Output:
+	// 1
+	// 4
+	// 9
+	// 16
+}
+
+func ExampleWithStageWorkers() {
+	p := gloop.NewPipeline(gloop.Interval(1, 5, 1))
+	stage := gloop.Stage(p, func(value int) int {
+		time.Sleep(time.Duration(5-value) * 10 * time.Millisecond)
+
+		return value * value
+	}, gloop.WithStageWorkers(4))
+
+	for value := range stage.Seq() {
+		fmt.Println(value)
+	}
+	// Output:
+	// 16
+	// 9
+	// 4
+	// 1
+}
+
+func ExampleWithStageBuffer() {
+	var processed atomic.Int64
+
+	p := gloop.NewPipeline(gloop.Interval(1, 100, 1))
+	stage := gloop.Stage(p, func(value int) int {
+		processed.Add(1)
+
+		return value
+	}, gloop.WithStageBuffer(3))
+
+	for range stage.Seq() {
+		time.Sleep(10 * time.Millisecond)
+		fmt.Println(processed.Load() <= 5)
+
+		break
+	}
+	// Output:
+	// true
+}
+
+func ExampleNewPool() {
+	pool := gloop.NewPool(gloop.WithPoolWorkers(2))
+
+	for range 3 {
+		gloop.Parallelize(gloop.Interval(0, 4, 1), func(value int) {}, gloop.WithParallelizePool(pool))
+	}
+
+	pool.Close()
+
+	fmt.Println(pool.Stats().Completed)
+	// Output:
+	// 12
+}
+
+func ExampleWithParallelizePool() {
+	pool := gloop.NewPool(gloop.WithPoolWorkers(4))
+	defer pool.Close()
+
+	var sum atomic.Int64
+
+	gloop.Parallelize(gloop.Interval(1, 5, 1), func(value int) {
+		sum.Add(int64(value))
+	}, gloop.WithParallelizePool(pool))
+
+	fmt.Println(sum.Load())
+	// Output:
+	// 10
+}
+
+func ExampleDAG() {
+	errTestFailed := errors.New("test failed")
+
+	seq, err := gloop.DAG([]gloop.DAGTask[string]{
+		{
+			Key:       "deploy",
+			DependsOn: []string{"test"},
+			Run: func(_ context.Context) error {
+				return nil
+			},
+		},
+		{
+			Key:       "test",
+			DependsOn: []string{"build"},
+			Run: func(_ context.Context) error {
+				return errTestFailed
+			},
+		},
+		{
+			Key: "build",
+			Run: func(_ context.Context) error {
+				return nil
+			},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	for event := range seq {
+		fmt.Println(event.Key, event.Status, event.Err)
+	}
+	// Output:
+	// build succeeded <nil>
+	// test failed test failed
+	// deploy skipped dependency test did not succeed: test failed
+}
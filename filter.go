@@ -35,3 +35,46 @@ func Filter2[K, V any](seq iter.Seq2[K, V], f Filter2Func[K, V]) iter.Seq2[K, V]
 		}
 	}
 }
+
+// FilterErrFunc is the function signature of the filtering function
+// used in [FilterErr].
+type FilterErrFunc[V any] func(V) (bool, error)
+
+// FilterErr runs a given function on each value from an [iter.Seq2]
+// sequence of values and errors and allows looping over values for
+// which the function returns true. Errors from the sequence are passed
+// through without running the function. Each error is yielded along
+// with a zero value, and by default looping ends at the first error.
+func FilterErr[V any](
+	seq iter.Seq2[V, error],
+	f FilterErrFunc[V],
+	opts ...ErrorOptionFunc,
+) iter.Seq2[V, error] {
+	options := newErrorOptions(opts...)
+
+	return func(yield func(V, error) bool) {
+		for value, err := range seq {
+			ok := false
+			if err == nil {
+				ok, err = f(value)
+			}
+
+			if err != nil {
+				var zero V
+				if !yield(zero, err) || !options.Collect {
+					return
+				}
+
+				continue
+			}
+
+			if !ok {
+				continue
+			}
+
+			if !yield(value, nil) {
+				return
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package gloop_test
 
 import (
+	"errors"
 	"testing"
 	"unicode"
 
@@ -134,3 +135,71 @@ func TestFilter2Break(t *testing.T) {
 
 	require.Equal(t, 2, i)
 }
+
+func TestFilterErrStop(t *testing.T) {
+	errSource := errors.New("source error")
+	values := []int{3, 4, 5, 6}
+	errs := []error{nil, nil, errSource, nil}
+	wantValues := []int{3, 0}
+	wantErrs := []error{nil, errSource}
+	i := 0
+
+	for value, err := range gloop.FilterErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(errs)),
+		func(value int) (bool, error) {
+			return value%2 == 1, nil
+		},
+	) {
+		require.Equal(t, wantValues[i], value)
+		require.Equal(t, wantErrs[i], err)
+
+		i++
+	}
+
+	require.Equal(t, len(wantValues), i)
+}
+
+func TestFilterErrCollect(t *testing.T) {
+	errOdd := errors.New("odd value")
+	values := []int{3, 4, 5, 6}
+	wantValues := []int{0, 4, 0, 6}
+	wantErrs := []error{errOdd, nil, errOdd, nil}
+	i := 0
+
+	for value, err := range gloop.FilterErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(make([]error, len(values)))),
+		func(value int) (bool, error) {
+			if value%2 == 1 {
+				return false, errOdd
+			}
+
+			return true, nil
+		},
+		gloop.WithErrorCollect(true),
+	) {
+		require.Equal(t, wantValues[i], value)
+		require.Equal(t, wantErrs[i], err)
+
+		i++
+	}
+
+	require.Equal(t, len(wantValues), i)
+}
+
+func TestFilterErrBreak(t *testing.T) {
+	values := []int{3, 4, 5, 6}
+	i := 0
+
+	for range gloop.FilterErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(make([]error, len(values)))),
+		func(value int) (bool, error) {
+			return value%2 == 0, nil
+		},
+	) {
+		i++
+
+		break
+	}
+
+	require.Equal(t, 1, i)
+}
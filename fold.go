@@ -1,6 +1,9 @@
 package gloop
 
-import "iter"
+import (
+	"errors"
+	"iter"
+)
 
 // FoldOptions defines configurable options for [Fold] and [Fold2].
 type FoldOptions[A any] struct {
@@ -66,3 +69,86 @@ func Fold2[A, K, V any](
 
 	return acc
 }
+
+// FoldErrOptions defines configurable options for [FoldErr].
+type FoldErrOptions[A any] struct {
+	// InitialValue is the starting value before folding.
+	InitialValue *A
+	// Collect indicates whether folding continues past errors. If
+	// false, folding ends at the first error.
+	Collect bool
+}
+
+// FoldErrOptionFunc is the function signature of configuration helpers
+// for [FoldErr].
+type FoldErrOptionFunc[A any] func(*FoldErrOptions[A])
+
+// WithFoldErrInitialValue is a helper for configuring initial value for
+// [FoldErr].
+func WithFoldErrInitialValue[A any](initialValue A) FoldErrOptionFunc[A] {
+	return func(o *FoldErrOptions[A]) {
+		o.InitialValue = &initialValue
+	}
+}
+
+// WithFoldErrCollect is a helper for configuring [FoldErr] to continue
+// folding past errors.
+func WithFoldErrCollect[A any](collect bool) FoldErrOptionFunc[A] {
+	return func(o *FoldErrOptions[A]) {
+		o.Collect = collect
+	}
+}
+
+// FoldErrFunc is the function signature of the folding function used
+// in [FoldErr].
+type FoldErrFunc[A, V any] func(A, V) (A, error)
+
+// FoldErr runs a given function on each value from an [iter.Seq2]
+// sequence of values and errors and accumulates the result into a
+// single value. By default, folding ends at the first error from the
+// sequence or the function, which is returned along with the value
+// accumulated before it. If configured to collect errors, values that
+// come with or cause an error are skipped and all errors are returned
+// joined together.
+func FoldErr[A, V any](
+	seq iter.Seq2[V, error],
+	f FoldErrFunc[A, V],
+	opts ...FoldErrOptionFunc[A],
+) (A, error) {
+	options := FoldErrOptions[A]{
+		InitialValue: nil,
+		Collect:      false,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	var acc A
+	if options.InitialValue != nil {
+		acc = *options.InitialValue
+	}
+
+	errs := make([]error, 0)
+
+	for value, err := range seq {
+		next := acc
+		if err == nil {
+			next, err = f(acc, value)
+		}
+
+		if err != nil {
+			if !options.Collect {
+				return acc, err
+			}
+
+			errs = append(errs, err)
+
+			continue
+		}
+
+		acc = next
+	}
+
+	return acc, errors.Join(errs...)
+}
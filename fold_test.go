@@ -1,6 +1,8 @@
 package gloop_test
 
 import (
+	"errors"
+	"strconv"
 	"testing"
 	"unicode"
 
@@ -88,3 +90,56 @@ func TestFold2MapProductOfValues(t *testing.T) {
 	}, gloop.WithFoldInitialValue(1))
 	require.Equal(t, 20, product)
 }
+
+func TestFoldErr(t *testing.T) {
+	values := []string{"3", "4", "5"}
+
+	sum, err := gloop.FoldErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(make([]error, len(values)))),
+		func(acc int, value string) (int, error) {
+			n, err := strconv.Atoi(value)
+
+			return acc + n, err
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, 12, sum)
+}
+
+func TestFoldErrStop(t *testing.T) {
+	errSource := errors.New("source error")
+	values := []string{"3", "4", "5", "x"}
+	errs := []error{nil, nil, errSource, nil}
+
+	sum, err := gloop.FoldErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(errs)),
+		func(acc int, value string) (int, error) {
+			n, err := strconv.Atoi(value)
+
+			return acc + n, err
+		},
+		gloop.WithFoldErrInitialValue(10),
+	)
+	require.ErrorIs(t, err, errSource)
+	require.Equal(t, 17, sum)
+}
+
+func TestFoldErrCollect(t *testing.T) {
+	errSource := errors.New("source error")
+	values := []string{"3", "x", "5", "6"}
+	errs := []error{nil, nil, errSource, nil}
+
+	sum, err := gloop.FoldErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(errs)),
+		func(acc int, value string) (int, error) {
+			n, err := strconv.Atoi(value)
+
+			return acc + n, err
+		},
+		gloop.WithFoldErrInitialValue(10),
+		gloop.WithFoldErrCollect[int](true),
+	)
+	require.ErrorIs(t, err, errSource)
+	require.ErrorIs(t, err, strconv.ErrSyntax)
+	require.Equal(t, 19, sum)
+}
@@ -0,0 +1,116 @@
+package gloop
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// ErrNotJSONArray is the error yielded by [JSONArray] when the input
+// is not a JSON array.
+var ErrNotJSONArray = errors.New("not a JSON array")
+
+// JSONLines allows looping over values decoded one at a time from an
+// [io.Reader] of whitespace separated JSON values, such as JSON Lines.
+// If a value does not match the given type, an
+// [encoding/json.UnmarshalTypeError] is yielded along with a zero value
+// and looping continues. If reading or parsing fails, the error is
+// yielded along with a zero value and looping ends.
+func JSONLines[T any](r io.Reader) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		decoder := json.NewDecoder(r)
+
+		for {
+			var value T
+
+			err := decoder.Decode(&value)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if !yieldJSONValue(yield, value, err) {
+				return
+			}
+		}
+	}
+}
+
+// JSONArray allows looping over the elements of a top-level JSON array
+// read from an [io.Reader], decoded one at a time so that the whole
+// array is never held in memory. If the input is not a JSON array,
+// [ErrNotJSONArray] is yielded. If an element does not match the given
+// type, an [encoding/json.UnmarshalTypeError] is yielded along with a
+// zero value and looping continues. If reading or parsing fails, the
+// error is yielded along with a zero value and looping ends.
+func JSONArray[T any](r io.Reader) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		decoder := json.NewDecoder(r)
+
+		token, err := decoder.Token()
+		if err != nil {
+			yield(zero, fmt.Errorf("failed to read JSON token: %w", err))
+
+			return
+		}
+
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			yield(zero, fmt.Errorf("unexpected JSON token %v: %w", token, ErrNotJSONArray))
+
+			return
+		}
+
+		for decoder.More() {
+			var value T
+
+			err := decoder.Decode(&value)
+			if !yieldJSONValue(yield, value, err) {
+				return
+			}
+		}
+
+		_, err = decoder.Token()
+		if err != nil {
+			yield(zero, fmt.Errorf("failed to read JSON token: %w", err))
+		}
+	}
+}
+
+// yieldJSONValue yields a decoded value, or the error from decoding it
+// along with a zero value. It returns whether or not looping should
+// continue.
+func yieldJSONValue[T any](yield func(T, error) bool, value T, err error) bool {
+	var zero T
+
+	if err == nil {
+		return yield(value, nil)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return yield(zero, err)
+	}
+
+	yield(zero, fmt.Errorf("failed to decode JSON value: %w", err))
+
+	return false
+}
+
+// ToJSONLines writes values from an [iter.Seq] sequence to an
+// [io.Writer] as JSON Lines, with each value encoded as JSON on its own
+// line.
+func ToJSONLines[V any](w io.Writer, seq iter.Seq[V]) error {
+	encoder := json.NewEncoder(w)
+
+	for value := range seq {
+		err := encoder.Encode(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON value: %w", err)
+		}
+	}
+
+	return nil
+}
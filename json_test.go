@@ -0,0 +1,294 @@
+package gloop_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+type jsonAnimal struct {
+	Name string `json:"name"`
+	Legs int    `json:"legs"`
+}
+
+func TestJSONLines(t *testing.T) {
+	r := strings.NewReader(`{"name": "CAT", "legs": 4}
+{"name": "CHICKEN", "legs": 2}
+
+{"name": "MOUSE"}
+`)
+	wantAnimals := []jsonAnimal{
+		{Name: "CAT", Legs: 4},
+		{Name: "CHICKEN", Legs: 2},
+		{Name: "MOUSE"},
+	}
+	i := 0
+
+	for animal, err := range gloop.JSONLines[jsonAnimal](r) {
+		require.NoError(t, err)
+		require.Equal(t, wantAnimals[i], animal)
+
+		i++
+	}
+
+	require.Equal(t, len(wantAnimals), i)
+}
+
+func TestJSONLinesTypeError(t *testing.T) {
+	r := strings.NewReader(`{"name": "CAT", "legs": 4}
+{"name": "CHICKEN", "legs": "two"}
+{"name": "MOUSE", "legs": 4}
+`)
+	wantNames := []string{"CAT", "", "MOUSE"}
+	i := 0
+
+	for animal, err := range gloop.JSONLines[jsonAnimal](r) {
+		require.Equal(t, wantNames[i], animal.Name)
+
+		if i == 1 {
+			var typeErr *json.UnmarshalTypeError
+			require.ErrorAs(t, err, &typeErr)
+		} else {
+			require.NoError(t, err)
+		}
+
+		i++
+	}
+
+	require.Equal(t, len(wantNames), i)
+}
+
+func TestJSONLinesSyntaxError(t *testing.T) {
+	r := strings.NewReader(`{"name": "CAT"}
+{"name": CHICKEN}
+{"name": "MOUSE"}
+`)
+	i := 0
+
+	for animal, err := range gloop.JSONLines[jsonAnimal](r) {
+		if i == 0 {
+			require.NoError(t, err)
+			require.Equal(t, "CAT", animal.Name)
+		} else {
+			var syntaxErr *json.SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			require.Equal(t, jsonAnimal{}, animal)
+		}
+
+		i++
+	}
+
+	require.Equal(t, 2, i)
+}
+
+func TestJSONLinesReadError(t *testing.T) {
+	r := io.MultiReader(strings.NewReader(`{"name": "CAT"}`+"\n"), iotest.ErrReader(errReaderTest))
+	var gotErr error
+
+	for _, err := range gloop.JSONLines[jsonAnimal](r) {
+		gotErr = err
+	}
+
+	require.ErrorIs(t, gotErr, errReaderTest)
+}
+
+func TestJSONLinesBreak(t *testing.T) {
+	r := strings.NewReader(`1 2 3`)
+
+	for value, err := range gloop.JSONLines[int](r) {
+		require.NoError(t, err)
+		require.Equal(t, 1, value)
+
+		break
+	}
+}
+
+func TestJSONLinesTypeErrorBreak(t *testing.T) {
+	r := strings.NewReader(`"one" 2 3`)
+
+	for _, err := range gloop.JSONLines[int](r) {
+		require.Error(t, err)
+
+		break
+	}
+}
+
+func TestJSONArray(t *testing.T) {
+	r := iotest.OneByteReader(strings.NewReader(`[
+	{"name": "CAT", "legs": 4},
+	{"name": "CHICKEN", "legs": 2}
+]`))
+	wantAnimals := []jsonAnimal{
+		{Name: "CAT", Legs: 4},
+		{Name: "CHICKEN", Legs: 2},
+	}
+	i := 0
+
+	for animal, err := range gloop.JSONArray[jsonAnimal](r) {
+		require.NoError(t, err)
+		require.Equal(t, wantAnimals[i], animal)
+
+		i++
+	}
+
+	require.Equal(t, len(wantAnimals), i)
+}
+
+func TestJSONArrayEmpty(t *testing.T) {
+	for range gloop.JSONArray[int](strings.NewReader(`[]`)) {
+		t.Fatal("expected no iteration")
+	}
+}
+
+func TestJSONArrayTypeError(t *testing.T) {
+	r := strings.NewReader(`[3, "one", 4]`)
+	wantValues := []int{3, 0, 4}
+	i := 0
+
+	for value, err := range gloop.JSONArray[int](r) {
+		require.Equal(t, wantValues[i], value)
+
+		if i == 1 {
+			var typeErr *json.UnmarshalTypeError
+			require.ErrorAs(t, err, &typeErr)
+		} else {
+			require.NoError(t, err)
+		}
+
+		i++
+	}
+
+	require.Equal(t, len(wantValues), i)
+}
+
+func TestJSONArrayNotArray(t *testing.T) {
+	testcases := map[string]string{
+		"Object": `{"name": "CAT"}`,
+		"Number": `42`,
+	}
+
+	for name, input := range testcases {
+		t.Run(name, func(t *testing.T) {
+			i := 0
+
+			for value, err := range gloop.JSONArray[int](strings.NewReader(input)) {
+				require.Equal(t, 0, value)
+				require.ErrorIs(t, err, gloop.ErrNotJSONArray)
+
+				i++
+			}
+
+			require.Equal(t, 1, i)
+		})
+	}
+}
+
+func TestJSONArrayEmptyInput(t *testing.T) {
+	i := 0
+
+	for _, err := range gloop.JSONArray[int](strings.NewReader("")) {
+		require.ErrorIs(t, err, io.EOF)
+
+		i++
+	}
+
+	require.Equal(t, 1, i)
+}
+
+func TestJSONArrayUnterminated(t *testing.T) {
+	testcases := map[string]string{
+		"Unclosed":   `[3, 1`,
+		"Mismatched": `[3, 1}`,
+	}
+
+	for name, input := range testcases {
+		t.Run(name, func(t *testing.T) {
+			wantValues := []int{3, 1, 0}
+			i := 0
+
+			for value, err := range gloop.JSONArray[int](strings.NewReader(input)) {
+				require.Equal(t, wantValues[i], value)
+
+				if i == 2 {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+				}
+
+				i++
+			}
+
+			require.Equal(t, len(wantValues), i)
+		})
+	}
+}
+
+func TestJSONArraySyntaxError(t *testing.T) {
+	r := strings.NewReader(`[3, x, 4]`)
+	i := 0
+
+	for value, err := range gloop.JSONArray[int](r) {
+		if i == 0 {
+			require.NoError(t, err)
+			require.Equal(t, 3, value)
+		} else {
+			var syntaxErr *json.SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+		}
+
+		i++
+	}
+
+	require.Equal(t, 2, i)
+}
+
+func TestJSONArrayBreak(t *testing.T) {
+	r := strings.NewReader(`[3, 1, 4]`)
+
+	for value, err := range gloop.JSONArray[int](r) {
+		require.NoError(t, err)
+		require.Equal(t, 3, value)
+
+		break
+	}
+}
+
+func TestToJSONLines(t *testing.T) {
+	var b bytes.Buffer
+	animals := []jsonAnimal{
+		{Name: "CAT", Legs: 4},
+		{Name: "CHICKEN", Legs: 2},
+	}
+
+	err := gloop.ToJSONLines(&b, gloop.Slice(animals))
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"CAT","legs":4}`+"\n"+`{"name":"CHICKEN","legs":2}`+"\n", b.String())
+
+	i := 0
+	for animal, err := range gloop.JSONLines[jsonAnimal](&b) {
+		require.NoError(t, err)
+		require.Equal(t, animals[i], animal)
+
+		i++
+	}
+
+	require.Equal(t, len(animals), i)
+}
+
+func TestToJSONLinesEncodeError(t *testing.T) {
+	var b bytes.Buffer
+
+	err := gloop.ToJSONLines(&b, gloop.Collect(func() {}))
+	require.Error(t, err)
+}
+
+func TestToJSONLinesWriteError(t *testing.T) {
+	err := gloop.ToJSONLines(failingWriter{}, gloop.Collect(1))
+	require.ErrorIs(t, err, errWriterTest)
+}
@@ -0,0 +1,235 @@
+package gloop
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// MicroBatchOptions defines configurable options for [MicroBatch].
+type MicroBatchOptions struct {
+	// MaxLatency defines the longest time the first value of a batch
+	// waits before the batch is flushed. If zero, batches are only
+	// flushed once full or once the sequence ends. If not zero, the
+	// sequence is consumed on a separate goroutine, which stops the
+	// next time the sequence yields after [MicroBatch] returns.
+	MaxLatency time.Duration
+	// MaxThreads defines the maximum number of handlers running
+	// concurrently. It must be positive.
+	MaxThreads int
+	// Context is used to send a cancel signal.
+	Context context.Context
+	// Clock is used to wait for MaxLatency.
+	Clock Clock
+}
+
+// MicroBatchOptionFunc is the function signature of configuration
+// helpers for [MicroBatch].
+type MicroBatchOptionFunc func(*MicroBatchOptions)
+
+// WithMicroBatchMaxLatency is a helper for configuring the longest time
+// the first value of a batch waits before the batch is flushed in
+// [MicroBatch].
+func WithMicroBatchMaxLatency(maxLatency time.Duration) MicroBatchOptionFunc {
+	return func(o *MicroBatchOptions) {
+		o.MaxLatency = maxLatency
+	}
+}
+
+// WithMicroBatchMaxThreads is a helper for configuring the maximum
+// number of handlers running concurrently in [MicroBatch].
+func WithMicroBatchMaxThreads(maxThreads int) MicroBatchOptionFunc {
+	return func(o *MicroBatchOptions) {
+		o.MaxThreads = maxThreads
+	}
+}
+
+// WithMicroBatchContext is a helper for configuring context in
+// [MicroBatch].
+func WithMicroBatchContext(ctx context.Context) MicroBatchOptionFunc {
+	return func(o *MicroBatchOptions) {
+		o.Context = ctx
+	}
+}
+
+// WithMicroBatchClock is a helper for configuring the clock in
+// [MicroBatch].
+func WithMicroBatchClock(clock Clock) MicroBatchOptionFunc {
+	return func(o *MicroBatchOptions) {
+		o.Clock = clock
+	}
+}
+
+// MicroBatchFunc is the function signature of the handler used in
+// [MicroBatch].
+type MicroBatchFunc[V any] func(context.Context, []V) error
+
+// MicroBatchError is the error returned by [MicroBatch] for each batch
+// whose handler fails.
+type MicroBatchError[V any] struct {
+	// Index is the position of the batch, starting at 0.
+	Index int
+	// Values are the values in the batch.
+	Values []V
+	// Err is the error returned by the handler.
+	Err error
+}
+
+// Error returns the error message.
+func (e *MicroBatchError[V]) Error() string {
+	return fmt.Sprintf("batch %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error returned by the handler.
+func (e *MicroBatchError[V]) Unwrap() error {
+	return e.Err
+}
+
+// MicroBatch groups values from an [iter.Seq] sequence into batches of
+// at most a given size and runs a given handler on each batch. A batch
+// is flushed once it is full, once the maximum latency has passed
+// since its first value, or once the sequence ends. The batch size
+// must be positive. By default, handlers run one at a time in the
+// order of the batches, and each batch waits for the previous handler
+// to return. Processing continues past failed batches, and a
+// [*MicroBatchError] for each of them is returned joined together.
+// Once the context is cancelled, no further values are pulled from the
+// sequence and values waiting in a batch are dropped. If the context
+// is cancelled and no handler returns an error, the context's error is
+// returned.
+func MicroBatch[V any](
+	seq iter.Seq[V],
+	size int,
+	handler MicroBatchFunc[V],
+	opts ...MicroBatchOptionFunc,
+) error {
+	if size <= 0 {
+		panic("size must be positive")
+	}
+
+	options := MicroBatchOptions{
+		MaxLatency: 0,
+		MaxThreads: 1,
+		Context:    context.Background(),
+		Clock:      SystemClock{},
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.MaxLatency < 0 {
+		panic("max latency must not be negative")
+	}
+
+	if options.MaxThreads <= 0 {
+		panic("max threads must be positive")
+	}
+
+	if options.Context == nil {
+		options.Context = context.Background()
+	}
+
+	return ParallelizeErr2(
+		Enumerate(microBatches(seq, size, options)),
+		func(ctx context.Context, i int, batch []V) error {
+			err := handler(ctx, batch)
+			if err != nil {
+				return &MicroBatchError[V]{
+					Index:  i,
+					Values: batch,
+					Err:    err,
+				}
+			}
+
+			return nil
+		},
+		WithParallelizeContext(options.Context),
+		WithParallelizeMaxThreads(options.MaxThreads),
+		WithParallelizeJoinErrors(true),
+	)
+}
+
+// microBatches allows looping over batches of an [iter.Seq] sequence
+// flushed by size, by maximum latency or at the end of the sequence.
+func microBatches[V any](seq iter.Seq[V], size int, options MicroBatchOptions) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		batch := make([]V, 0, size)
+
+		if options.MaxLatency == 0 {
+			for value := range seq {
+				batch = append(batch, value)
+				if len(batch) == size {
+					if !yield(batch) {
+						return
+					}
+
+					batch = make([]V, 0, size)
+				}
+
+				if options.Context.Err() != nil {
+					return
+				}
+			}
+
+			if len(batch) > 0 {
+				yield(batch)
+			}
+
+			return
+		}
+
+		values := make(chan V)
+		done := make(chan struct{})
+
+		defer close(done)
+
+		go func() {
+			defer close(values)
+
+			for value := range seq {
+				select {
+				case values <- value:
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		var timeout <-chan time.Time
+
+		for {
+			select {
+			case value, ok := <-values:
+				if !ok {
+					if len(batch) > 0 {
+						yield(batch)
+					}
+
+					return
+				}
+
+				if len(batch) == 0 {
+					timeout = options.Clock.After(options.MaxLatency)
+				}
+
+				batch = append(batch, value)
+				if len(batch) < size {
+					continue
+				}
+			case <-timeout:
+			case <-options.Context.Done():
+				return
+			}
+
+			timeout = nil
+
+			if !yield(batch) {
+				return
+			}
+
+			batch = make([]V, 0, size)
+		}
+	}
+}
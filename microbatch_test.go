@@ -0,0 +1,416 @@
+package gloop_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMicroBatchMaxLatency(t *testing.T) {
+	options := gloop.MicroBatchOptions{}
+	gloop.WithMicroBatchMaxLatency(time.Second)(&options)
+
+	require.Equal(t, time.Second, options.MaxLatency)
+}
+
+func TestWithMicroBatchMaxThreads(t *testing.T) {
+	options := gloop.MicroBatchOptions{}
+	gloop.WithMicroBatchMaxThreads(4)(&options)
+
+	require.Equal(t, 4, options.MaxThreads)
+}
+
+func TestWithMicroBatchContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	options := gloop.MicroBatchOptions{}
+	gloop.WithMicroBatchContext(ctx)(&options)
+
+	require.Equal(t, ctx, options.Context)
+}
+
+func TestWithMicroBatchClock(t *testing.T) {
+	clock := newFakeClock(time.Now())
+	options := gloop.MicroBatchOptions{}
+	gloop.WithMicroBatchClock(clock)(&options)
+
+	require.Equal(t, clock, options.Clock)
+}
+
+func TestMicroBatchError(t *testing.T) {
+	errFizz := errors.New("fizz error")
+	err := &gloop.MicroBatchError[int]{
+		Index:  2,
+		Values: []int{3, 1, 4},
+		Err:    errFizz,
+	}
+
+	require.Equal(t, "batch 2: fizz error", err.Error())
+	require.ErrorIs(t, err, errFizz)
+}
+
+func TestMicroBatchSize(t *testing.T) {
+	testcases := map[string]struct {
+		values      []int
+		size        int
+		wantBatches [][]int
+	}{
+		"Full batches": {
+			values:      []int{3, 1, 4, 1, 5, 9},
+			size:        3,
+			wantBatches: [][]int{{3, 1, 4}, {1, 5, 9}},
+		},
+		"Partial last batch": {
+			values:      []int{3, 1, 4, 1, 5, 9, 2},
+			size:        3,
+			wantBatches: [][]int{{3, 1, 4}, {1, 5, 9}, {2}},
+		},
+		"Size larger than sequence": {
+			values:      []int{3, 1},
+			size:        5,
+			wantBatches: [][]int{{3, 1}},
+		},
+		"Empty sequence": {
+			values:      []int{},
+			size:        2,
+			wantBatches: [][]int{},
+		},
+	}
+
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			gotBatches := make([][]int, 0)
+
+			err := gloop.MicroBatch(gloop.Slice(testcase.values), testcase.size, func(_ context.Context, batch []int) error {
+				gotBatches = append(gotBatches, batch)
+
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, testcase.wantBatches, gotBatches)
+		})
+	}
+}
+
+func TestMicroBatchMaxLatency(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	ch := make(chan int)
+	pulled := make(chan struct{})
+	batches := make(chan []int, 10)
+	done := make(chan error, 1)
+
+	// seq signals once each value has been received by MicroBatch.
+	seq := func(yield func(int) bool) {
+		for value := range ch {
+			if !yield(value) {
+				return
+			}
+
+			pulled <- struct{}{}
+		}
+	}
+
+	go func() {
+		done <- gloop.MicroBatch(seq, 3, func(_ context.Context, batch []int) error {
+			batches <- batch
+
+			return nil
+		}, gloop.WithMicroBatchMaxLatency(time.Second), gloop.WithMicroBatchClock(clock))
+	}()
+
+	receive := func() []int {
+		select {
+		case batch := <-batches:
+			return batch
+		case <-time.After(time.Second * 10):
+			t.Fatal("batch took too long")
+		}
+
+		return nil
+	}
+
+	ch <- 3
+	<-pulled
+	clock.WaitForAfter(t)
+	ch <- 1
+	<-pulled
+	clock.Advance(time.Second)
+	require.Equal(t, []int{3, 1}, receive())
+
+	ch <- 4
+	<-pulled
+	clock.WaitForAfter(t)
+	ch <- 1
+	<-pulled
+	ch <- 5
+	<-pulled
+	require.Equal(t, []int{4, 1, 5}, receive())
+
+	ch <- 9
+	<-pulled
+	clock.WaitForAfter(t)
+	close(ch)
+	require.Equal(t, []int{9}, receive())
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestMicroBatchMaxLatencyEmptySequence(t *testing.T) {
+	called := false
+
+	err := gloop.MicroBatch(gloop.Slice([]int{}), 3, func(_ context.Context, _ []int) error {
+		called = true
+
+		return nil
+	}, gloop.WithMicroBatchMaxLatency(time.Second))
+	require.NoError(t, err)
+	require.False(t, called)
+}
+
+func TestMicroBatchMaxThreads(t *testing.T) {
+	var (
+		mu                   sync.Mutex
+		concurrentCallers    atomic.Int64
+		maxConcurrentCallers atomic.Int64
+	)
+
+	gotValues := make([]int, 0)
+
+	err := gloop.MicroBatch(gloop.Interval(0, 24, 1), 2, func(_ context.Context, batch []int) error {
+		n := concurrentCallers.Add(1)
+		defer concurrentCallers.Add(-1)
+
+		for {
+			m := maxConcurrentCallers.Load()
+			if n <= m || maxConcurrentCallers.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond * 5)
+
+		mu.Lock()
+		gotValues = append(gotValues, batch...)
+		mu.Unlock()
+
+		return nil
+	}, gloop.WithMicroBatchMaxThreads(3))
+	require.NoError(t, err)
+	require.EqualValues(t, 3, maxConcurrentCallers.Load())
+
+	slices.Sort(gotValues)
+	require.Equal(t, gloop.ToSlice(gloop.Interval(0, 24, 1)), gotValues)
+}
+
+func TestMicroBatchErrors(t *testing.T) {
+	errOdd := errors.New("odd batch")
+	handled := 0
+
+	err := gloop.MicroBatch(gloop.Interval(0, 10, 1), 2, func(_ context.Context, batch []int) error {
+		handled++
+
+		if batch[0]%4 == 2 {
+			return errOdd
+		}
+
+		return nil
+	})
+	require.ErrorIs(t, err, errOdd)
+	require.Equal(t, 5, handled)
+
+	errs := err.(interface{ Unwrap() []error }).Unwrap()
+	require.Len(t, errs, 2)
+
+	wantBatchErrs := []*gloop.MicroBatchError[int]{
+		{Index: 1, Values: []int{2, 3}, Err: errOdd},
+		{Index: 3, Values: []int{6, 7}, Err: errOdd},
+	}
+
+	for i, err := range errs {
+		var batchErr *gloop.MicroBatchError[int]
+		require.ErrorAs(t, err, &batchErr)
+		require.Equal(t, wantBatchErrs[i], batchErr)
+	}
+}
+
+func TestMicroBatchCancelContext(t *testing.T) {
+	testcases := map[string]struct {
+		cancelAfter int
+		size        int
+		wantBatches [][]int
+	}{
+		"Cancel with partial batch": {
+			cancelAfter: 4,
+			size:        3,
+			wantBatches: [][]int{{0, 1, 2}},
+		},
+		"Cancel with full batch": {
+			cancelAfter: 5,
+			size:        3,
+			wantBatches: [][]int{{0, 1, 2}},
+		},
+	}
+
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+
+			handled := make(chan struct{}, 1)
+
+			seq := func(yield func(int) bool) {
+				for i := range 10 {
+					if i == testcase.cancelAfter {
+						<-handled
+						cancel()
+					}
+
+					if !yield(i) {
+						return
+					}
+				}
+			}
+
+			gotBatches := make([][]int, 0)
+
+			err := gloop.MicroBatch(seq, testcase.size, func(_ context.Context, batch []int) error {
+				gotBatches = append(gotBatches, batch)
+				handled <- struct{}{}
+
+				return nil
+			}, gloop.WithMicroBatchContext(ctx))
+			require.ErrorIs(t, err, context.Canceled)
+			require.Equal(t, testcase.wantBatches, gotBatches)
+		})
+	}
+}
+
+func TestMicroBatchMaxLatencyCancelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	clock := newFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	ch := make(chan int)
+	t.Cleanup(func() {
+		close(ch)
+	})
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- gloop.MicroBatch(gloop.Channel(ch), 3, func(_ context.Context, _ []int) error {
+			return nil
+		}, gloop.WithMicroBatchMaxLatency(time.Second), gloop.WithMicroBatchClock(clock), gloop.WithMicroBatchContext(ctx))
+	}()
+
+	ch <- 3
+	clock.WaitForAfter(t)
+	cancel()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestMicroBatchMaxLatencyCancelContextWhileWaiting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	ch := make(chan int)
+	t.Cleanup(func() {
+		close(ch)
+	})
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+
+	var handled atomic.Int64
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- gloop.MicroBatch(gloop.Channel(ch), 1, func(_ context.Context, _ []int) error {
+			handled.Add(1)
+			started <- struct{}{}
+			<-release
+
+			return nil
+		}, gloop.WithMicroBatchMaxLatency(time.Second), gloop.WithMicroBatchContext(ctx))
+	}()
+
+	ch <- 3
+
+	select {
+	case <-started:
+	case <-time.After(time.Second * 10):
+		t.Fatal("started signal took too long")
+	}
+
+	// Once 4 is received from the channel, the batch of 1 has been
+	// received from the sequence and is waiting for the first handler.
+	ch <- 1
+	ch <- 4
+	cancel()
+	close(release)
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 1, handled.Load())
+}
+
+func TestMicroBatchNilContext(t *testing.T) {
+	var ctx context.Context
+
+	gotBatches := make([][]int, 0)
+
+	err := gloop.MicroBatch(gloop.Interval(0, 3, 1), 2, func(_ context.Context, batch []int) error {
+		gotBatches = append(gotBatches, batch)
+
+		return nil
+	}, gloop.WithMicroBatchContext(ctx))
+	require.NoError(t, err)
+	require.Equal(t, [][]int{{0, 1}, {2}}, gotBatches)
+}
+
+func TestMicroBatchInvalidOptionsPanics(t *testing.T) {
+	handler := func(_ context.Context, _ []int) error {
+		return nil
+	}
+
+	require.Panics(t, func() {
+		_ = gloop.MicroBatch(gloop.Interval(0, 3, 1), 0, handler)
+	})
+
+	require.Panics(t, func() {
+		_ = gloop.MicroBatch(gloop.Interval(0, 3, 1), 2, handler, gloop.WithMicroBatchMaxLatency(-time.Second))
+	})
+
+	require.Panics(t, func() {
+		_ = gloop.MicroBatch(gloop.Interval(0, 3, 1), 2, handler, gloop.WithMicroBatchMaxThreads(0))
+	})
+}
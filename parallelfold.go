@@ -0,0 +1,146 @@
+package gloop
+
+import (
+	"iter"
+	"runtime"
+	"slices"
+)
+
+// defaultParallelFoldChunkSize is the number of values folded together
+// on each goroutine in [ParallelFold] when the chunk size is not
+// configured.
+const defaultParallelFoldChunkSize = 1024
+
+// ParallelFoldCombineFunc is the function signature of the combining
+// function used in [ParallelFold] and [ParallelFoldSlice].
+type ParallelFoldCombineFunc[A any] func(A, A) A
+
+// ParallelFold splits an [iter.Seq] sequence into chunks, runs a given
+// folding function on each value in each chunk on separate goroutines
+// starting from a given identity value, and combines the accumulated
+// values of the chunks in order using a given combining function. The
+// result is deterministic if the combining function is associative and
+// the identity value is its identity. If the chunk size is not
+// configured, it defaults to 1024. The sequence is consumed on a
+// separate goroutine. If the context is cancelled or a panic is
+// recovered, only the chunks folded before then are combined, and the
+// context's error or the first recovered panic as a [*PanicError] is
+// returned along with the result.
+func ParallelFold[A, V any](
+	seq iter.Seq[V],
+	identity A,
+	fold FoldFunc[A, V],
+	combine ParallelFoldCombineFunc[A],
+	opts ...ParallelizeOptionFunc,
+) (A, error) {
+	options := newParallelizeOptions(opts...)
+
+	chunkSize := options.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = defaultParallelFoldChunkSize
+	}
+
+	if chunkSize < 0 {
+		panic("chunk size must not be negative")
+	}
+
+	chunks := Transform(Batch(seq, chunkSize), ToSlice[V])
+
+	return parallelFoldChunks(chunks, identity, fold, combine, opts...)
+}
+
+// ParallelFoldSlice splits a slice into chunks, runs a given folding
+// function on each value in each chunk on separate goroutines starting
+// from a given identity value, and combines the accumulated values of
+// the chunks in order using a given combining function. Unlike
+// [ParallelFold], chunks share the memory of the slice rather than
+// being copied. The result is deterministic if the combining function
+// is associative and the identity value is its identity. If the chunk
+// size is not configured, the slice is split evenly between the
+// maximum number of threads. Errors are returned as in [ParallelFold].
+func ParallelFoldSlice[A, V any](
+	values []V,
+	identity A,
+	fold FoldFunc[A, V],
+	combine ParallelFoldCombineFunc[A],
+	opts ...ParallelizeOptionFunc,
+) (A, error) {
+	options := newParallelizeOptions(opts...)
+
+	chunkSize := options.ChunkSize
+	if chunkSize == 0 {
+		maxThreads := runtime.GOMAXPROCS(0)
+		if options.MaxThreads != nil {
+			maxThreads = *options.MaxThreads
+		}
+
+		chunkSize = max(1, (len(values)+maxThreads-1)/max(1, maxThreads))
+	}
+
+	if chunkSize < 0 {
+		panic("chunk size must not be negative")
+	}
+
+	chunks := Transform(Interval(0, len(values), chunkSize), func(low int) []V {
+		return values[low:min(low+chunkSize, len(values))]
+	})
+
+	return parallelFoldChunks(chunks, identity, fold, combine, opts...)
+}
+
+// parallelFoldChunks folds each chunk in an [iter.Seq] sequence of
+// chunks on separate goroutines and combines the results in order. It
+// returns the context's error or the first recovered panic, if any.
+func parallelFoldChunks[A, V any](
+	chunks iter.Seq[[]V],
+	identity A,
+	fold FoldFunc[A, V],
+	combine ParallelFoldCombineFunc[A],
+	opts ...ParallelizeOptionFunc,
+) (A, error) {
+	options := newParallelizeOptions(opts...)
+	validateNoValueFuncs(options)
+
+	var panicErr *PanicError
+
+	// recordPanic keeps the first recovered panic, passing every panic
+	// on to the panic handler, if any.
+	recordPanic := func(o *ParallelizeOptions) {
+		if !o.RecoverPanics && o.PanicHandler == nil && !o.Repanic {
+			return
+		}
+
+		handler := o.PanicHandler
+		o.PanicHandler = func(err *PanicError) {
+			if panicErr == nil {
+				panicErr = err
+			}
+
+			if handler != nil {
+				handler(err)
+			}
+		}
+	}
+
+	opts = append(slices.Clone(opts), WithParallelizeUnordered(false), recordPanic)
+
+	partials := ParallelTransform(chunks, func(chunk []V) A {
+		acc := identity
+		for _, value := range chunk {
+			acc = fold(acc, value)
+		}
+
+		return acc
+	}, opts...)
+
+	acc := identity
+	for partial := range partials {
+		acc = combine(acc, partial)
+	}
+
+	if panicErr != nil {
+		return acc, panicErr
+	}
+
+	return acc, options.Context.Err()
+}
@@ -0,0 +1,279 @@
+package gloop_test
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithParallelizeChunkSize(t *testing.T) {
+	options := gloop.ParallelizeOptions{}
+	gloop.WithParallelizeChunkSize(42)(&options)
+
+	require.Equal(t, 42, options.ChunkSize)
+}
+
+func TestParallelFoldSum(t *testing.T) {
+	testcases := map[string]struct {
+		n    int
+		opts []gloop.ParallelizeOptionFunc
+	}{
+		"Default options": {
+			n:    10000,
+			opts: nil,
+		},
+		"Chunk size 7": {
+			n: 1000,
+			opts: []gloop.ParallelizeOptionFunc{
+				gloop.WithParallelizeChunkSize(7),
+			},
+		},
+		"Chunk size larger than sequence": {
+			n: 10,
+			opts: []gloop.ParallelizeOptionFunc{
+				gloop.WithParallelizeChunkSize(100),
+			},
+		},
+		"Single thread": {
+			n: 1000,
+			opts: []gloop.ParallelizeOptionFunc{
+				gloop.WithParallelizeChunkSize(10),
+				gloop.WithParallelizeMaxThreads(1),
+			},
+		},
+		"Empty sequence": {
+			n:    0,
+			opts: nil,
+		},
+	}
+
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			add := func(acc int, value int) int {
+				return acc + value
+			}
+			want := gloop.Sum(gloop.Interval(0, testcase.n, 1))
+			values := gloop.ToSlice(gloop.Interval(0, testcase.n, 1))
+
+			got, err := gloop.ParallelFold(gloop.Interval(0, testcase.n, 1), 0, add, add, testcase.opts...)
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+
+			got, err = gloop.ParallelFoldSlice(values, 0, add, add, testcase.opts...)
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+		})
+	}
+}
+
+func TestParallelFoldDeterministic(t *testing.T) {
+	values := strings.Split("the quick brown fox jumps over the lazy dog", "")
+	want := gloop.Fold(gloop.Slice(values), func(acc string, value string) string {
+		return acc + value
+	})
+
+	fold := func(acc string, value string) string {
+		return acc + value
+	}
+	combine := func(acc string, partial string) string {
+		return acc + partial
+	}
+
+	for range 10 {
+		got, err := gloop.ParallelFold(gloop.Slice(values), "", fold, combine, gloop.WithParallelizeChunkSize(3))
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+
+		got, err = gloop.ParallelFoldSlice(values, "", fold, combine, gloop.WithParallelizeChunkSize(3))
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+
+		got, err = gloop.ParallelFoldSlice(values, "", fold, combine)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+}
+
+func TestParallelFoldSliceChunks(t *testing.T) {
+	values := gloop.ToSlice(gloop.Interval(0, 100, 1))
+
+	var chunks atomic.Int64
+
+	sum, err := gloop.ParallelFoldSlice(values, 0, func(acc int, value int) int {
+		return acc + value
+	}, func(acc int, partial int) int {
+		chunks.Add(1)
+
+		return acc + partial
+	}, gloop.WithParallelizeMaxThreads(4))
+	require.NoError(t, err)
+
+	require.Equal(t, 4950, sum)
+	require.EqualValues(t, 4, chunks.Load())
+}
+
+func TestParallelFoldSliceDefaultChunks(t *testing.T) {
+	values := gloop.ToSlice(gloop.Interval(0, 10*runtime.GOMAXPROCS(0), 1))
+
+	var chunks atomic.Int64
+
+	_, err := gloop.ParallelFoldSlice(values, 0, func(acc int, value int) int {
+		return acc + value
+	}, func(acc int, partial int) int {
+		chunks.Add(1)
+
+		return acc + partial
+	})
+	require.NoError(t, err)
+
+	require.EqualValues(t, runtime.GOMAXPROCS(0), chunks.Load())
+}
+
+func TestParallelFoldValueFuncsPanics(t *testing.T) {
+	add := func(acc int, value int) int {
+		return acc + value
+	}
+
+	weight := gloop.WithParallelizeWeight(2, func(v int) int64 {
+		return int64(v)
+	})
+	priority := gloop.WithParallelizePriority(func(v int) int {
+		return v
+	}, 4)
+
+	require.PanicsWithValue(t, "weight function is not supported", func() {
+		_, _ = gloop.ParallelFold(gloop.Interval(0, 100, 1), 0, add, add, weight)
+	})
+
+	require.PanicsWithValue(t, "priority function is not supported", func() {
+		_, _ = gloop.ParallelFoldSlice(gloop.ToSlice(gloop.Interval(0, 100, 1)), 0, add, add, priority)
+	})
+}
+
+func TestParallelFoldCancelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	add := func(acc int, value int) int {
+		return acc + value
+	}
+
+	sum, err := gloop.ParallelFold(gloop.Interval(1, 100, 1), 0, add, add, gloop.WithParallelizeContext(ctx))
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 0, sum)
+
+	sum, err = gloop.ParallelFoldSlice(
+		gloop.ToSlice(gloop.Interval(1, 100, 1)),
+		0,
+		add,
+		add,
+		gloop.WithParallelizeContext(ctx),
+	)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 0, sum)
+}
+
+func TestParallelFoldRecoverPanics(t *testing.T) {
+	fold := func(acc int, value int) int {
+		if value == 5 {
+			panic("fizz panic")
+		}
+
+		return acc + value
+	}
+	add := func(acc int, partial int) int {
+		return acc + partial
+	}
+
+	testcases := map[string]struct {
+		opts        []gloop.ParallelizeOptionFunc
+		wantHandled int64
+	}{
+		"Recover panics": {
+			opts: []gloop.ParallelizeOptionFunc{
+				gloop.WithParallelizeRecoverPanics(true),
+			},
+			wantHandled: 0,
+		},
+		"Panic handler": {
+			opts:        nil,
+			wantHandled: 1,
+		},
+	}
+
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var handled atomic.Int64
+
+			opts := append(
+				testcase.opts,
+				gloop.WithParallelizeChunkSize(2),
+				gloop.WithParallelizeMaxThreads(1),
+			)
+			if testcase.wantHandled != 0 {
+				opts = append(opts, gloop.WithParallelizePanicHandler(func(_ *gloop.PanicError) {
+					handled.Add(1)
+				}))
+			}
+
+			sum, err := gloop.ParallelFold(gloop.Interval(0, 10, 1), 0, fold, add, opts...)
+
+			var panicErr *gloop.PanicError
+			require.ErrorAs(t, err, &panicErr)
+			require.Equal(t, "fizz panic", panicErr.Value)
+			require.Equal(t, 6, sum)
+			require.Equal(t, testcase.wantHandled, handled.Load())
+		})
+	}
+}
+
+func TestParallelFoldRepanic(t *testing.T) {
+	fold := func(_ int, _ int) int {
+		panic("fizz panic")
+	}
+	add := func(acc int, partial int) int {
+		return acc + partial
+	}
+
+	done := make(chan any, 1)
+	go func() {
+		defer func() {
+			done <- recover()
+		}()
+
+		_, _ = gloop.ParallelFold(gloop.Interval(0, 10, 1), 0, fold, add, gloop.WithParallelizeRepanic(true))
+	}()
+
+	select {
+	case value := <-done:
+		panicErr, ok := value.(*gloop.PanicError)
+		require.True(t, ok)
+		require.Equal(t, "fizz panic", panicErr.Value)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestParallelFoldNegativeChunkSizePanics(t *testing.T) {
+	add := func(acc int, value int) int {
+		return acc + value
+	}
+
+	require.Panics(t, func() {
+		_, _ = gloop.ParallelFold(gloop.Interval(0, 10, 1), 0, add, add, gloop.WithParallelizeChunkSize(-1))
+	})
+
+	require.Panics(t, func() {
+		_, _ = gloop.ParallelFoldSlice([]int{3, 1, 4}, 0, add, add, gloop.WithParallelizeChunkSize(-1))
+	})
+}
@@ -0,0 +1,95 @@
+package gloop
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"slices"
+)
+
+// ParallelForFunc is the function signature of the function run on
+// each chunk of indices in [ParallelFor].
+type ParallelForFunc func(context.Context, int, int) error
+
+// ParallelFor splits the indices in a given interval, closed at the
+// start and open at the stop point, into contiguous chunks and runs a
+// given function on the low and high bounds of each chunk on separate
+// goroutines. If the chunk size is not configured, the interval is
+// split evenly between the maximum number of threads. If the maximum
+// number of threads is not configured, it defaults to
+// [runtime.GOMAXPROCS]. Errors are handled as in [ParallelizeErr].
+func ParallelFor(
+	start int,
+	stop int,
+	f ParallelForFunc,
+	opts ...ParallelizeOptionFunc,
+) error {
+	options := newParallelizeOptions(opts...)
+
+	maxThreads := runtime.GOMAXPROCS(0)
+	if options.MaxThreads != nil {
+		maxThreads = *options.MaxThreads
+	}
+
+	if maxThreads <= 0 {
+		panic("max threads must be positive")
+	}
+
+	chunkSize := options.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = max(1, (stop-start+maxThreads-1)/maxThreads)
+	}
+
+	if chunkSize < 0 {
+		panic("chunk size must not be negative")
+	}
+
+	validateNoValueFuncs(options)
+
+	opts = append(slices.Clone(opts), WithParallelizeMaxThreads(maxThreads))
+
+	return ParallelizeErr(Interval(start, stop, chunkSize), func(ctx context.Context, low int) error {
+		return f(ctx, low, min(low+chunkSize, stop))
+	}, opts...)
+}
+
+// ParallelForEachFunc is the function signature of the function run on
+// each index in [ParallelForEach].
+type ParallelForEachFunc func(context.Context, int) error
+
+// ParallelForEach splits the indices in a given interval, closed at
+// the start and open at the stop point, into contiguous chunks as in
+// [ParallelFor], and runs a given function on each index in each chunk
+// on separate goroutines. Indices in each chunk are processed in
+// order. By default, processing stops at the first error, which is
+// returned. If configured to join errors, all errors are returned
+// joined together. Once the context is cancelled, no further indices
+// are processed, and if no function returns an error, the context's
+// error is returned.
+func ParallelForEach(
+	start int,
+	stop int,
+	f ParallelForEachFunc,
+	opts ...ParallelizeOptionFunc,
+) error {
+	options := newParallelizeOptions(opts...)
+
+	return ParallelFor(start, stop, func(ctx context.Context, low int, high int) error {
+		errs := make([]error, 0)
+
+		for i := low; i < high && ctx.Err() == nil; i++ {
+			err := f(ctx, i)
+			if err == nil {
+				continue
+			}
+
+			if !options.JoinErrors {
+				return err
+			}
+
+			errs = append(errs, err)
+		}
+
+		return errors.Join(errs...)
+	}, opts...)
+}
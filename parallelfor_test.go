@@ -0,0 +1,284 @@
+package gloop_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParallelFor(t *testing.T) {
+	testcases := map[string]struct {
+		start      int
+		stop       int
+		opts       []gloop.ParallelizeOptionFunc
+		wantChunks [][2]int
+	}{
+		"Automatic chunk size": {
+			start: 0,
+			stop:  10,
+			opts: []gloop.ParallelizeOptionFunc{
+				gloop.WithParallelizeMaxThreads(4),
+			},
+			wantChunks: [][2]int{{0, 3}, {3, 6}, {6, 9}, {9, 10}},
+		},
+		"Configured chunk size": {
+			start: 5,
+			stop:  15,
+			opts: []gloop.ParallelizeOptionFunc{
+				gloop.WithParallelizeChunkSize(4),
+			},
+			wantChunks: [][2]int{{5, 9}, {9, 13}, {13, 15}},
+		},
+		"Chunk size larger than interval": {
+			start: -2,
+			stop:  2,
+			opts: []gloop.ParallelizeOptionFunc{
+				gloop.WithParallelizeChunkSize(10),
+			},
+			wantChunks: [][2]int{{-2, 2}},
+		},
+		"Fewer indices than threads": {
+			start: 0,
+			stop:  2,
+			opts: []gloop.ParallelizeOptionFunc{
+				gloop.WithParallelizeMaxThreads(4),
+			},
+			wantChunks: [][2]int{{0, 1}, {1, 2}},
+		},
+		"Empty interval": {
+			start:      3,
+			stop:       3,
+			opts:       nil,
+			wantChunks: [][2]int{},
+		},
+		"Reversed interval": {
+			start:      3,
+			stop:       1,
+			opts:       nil,
+			wantChunks: [][2]int{},
+		},
+	}
+
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var mu sync.Mutex
+
+			gotChunks := make([][2]int, 0)
+
+			err := gloop.ParallelFor(testcase.start, testcase.stop, func(_ context.Context, low int, high int) error {
+				mu.Lock()
+				gotChunks = append(gotChunks, [2]int{low, high})
+				mu.Unlock()
+
+				return nil
+			}, testcase.opts...)
+			require.NoError(t, err)
+
+			slices.SortFunc(gotChunks, func(a [2]int, b [2]int) int {
+				return a[0] - b[0]
+			})
+			require.Equal(t, testcase.wantChunks, gotChunks)
+		})
+	}
+}
+
+func TestParallelForMaxThreads(t *testing.T) {
+	var (
+		concurrentCallers    atomic.Int64
+		maxConcurrentCallers atomic.Int64
+	)
+
+	err := gloop.ParallelFor(0, 100, func(_ context.Context, _ int, _ int) error {
+		n := concurrentCallers.Add(1)
+		defer concurrentCallers.Add(-1)
+
+		for {
+			m := maxConcurrentCallers.Load()
+			if n <= m || maxConcurrentCallers.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond * 5)
+
+		return nil
+	}, gloop.WithParallelizeMaxThreads(3), gloop.WithParallelizeChunkSize(10))
+	require.NoError(t, err)
+	require.EqualValues(t, 3, maxConcurrentCallers.Load())
+}
+
+func TestParallelForError(t *testing.T) {
+	errFizz := errors.New("fizz error")
+
+	err := gloop.ParallelFor(0, 100, func(_ context.Context, low int, _ int) error {
+		if low == 30 {
+			return errFizz
+		}
+
+		return nil
+	}, gloop.WithParallelizeChunkSize(10))
+	require.ErrorIs(t, err, errFizz)
+}
+
+func TestParallelForCancelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+
+	err := gloop.ParallelFor(0, 100, func(_ context.Context, _ int, _ int) error {
+		called = true
+
+		return nil
+	}, gloop.WithParallelizeContext(ctx))
+	require.ErrorIs(t, err, context.Canceled)
+	require.False(t, called)
+}
+
+func TestParallelForInvalidOptionsPanics(t *testing.T) {
+	f := func(_ context.Context, _ int, _ int) error {
+		return nil
+	}
+
+	require.Panics(t, func() {
+		_ = gloop.ParallelFor(0, 10, f, gloop.WithParallelizeMaxThreads(0))
+	})
+
+	require.Panics(t, func() {
+		_ = gloop.ParallelFor(0, 10, f, gloop.WithParallelizeChunkSize(-1))
+	})
+}
+
+func TestParallelForEach(t *testing.T) {
+	values := make([]int, 1000)
+
+	err := gloop.ParallelForEach(0, len(values), func(_ context.Context, i int) error {
+		values[i] = i * i
+
+		return nil
+	}, gloop.WithParallelizeMaxThreads(4))
+	require.NoError(t, err)
+
+	for i, value := range values {
+		require.Equal(t, i*i, value)
+	}
+}
+
+func TestParallelForValueFuncsPanics(t *testing.T) {
+	f := func(_ context.Context, _ int) error {
+		return nil
+	}
+
+	require.PanicsWithValue(t, "weight function is not supported", func() {
+		_ = gloop.ParallelForEach(0, 100, f, gloop.WithParallelizeWeight(2, func(i int) int64 {
+			return int64(i)
+		}))
+	})
+
+	require.PanicsWithValue(t, "priority function is not supported", func() {
+		_ = gloop.ParallelForEach(0, 100, f, gloop.WithParallelizePriority(func(i int) int {
+			return i
+		}, 4))
+	})
+}
+
+func TestParallelForEachOrderedWithinChunk(t *testing.T) {
+	var mu sync.Mutex
+
+	gotIndices := make(map[int][]int)
+
+	err := gloop.ParallelForEach(0, 20, func(_ context.Context, i int) error {
+		mu.Lock()
+		gotIndices[i/5] = append(gotIndices[i/5], i)
+		mu.Unlock()
+
+		return nil
+	}, gloop.WithParallelizeChunkSize(5))
+	require.NoError(t, err)
+
+	for chunk, indices := range gotIndices {
+		require.Equal(t, gloop.ToSlice(gloop.Interval(chunk*5, chunk*5+5, 1)), indices)
+	}
+}
+
+func TestParallelForEachError(t *testing.T) {
+	errFizz := errors.New("fizz error")
+
+	var called atomic.Int64
+
+	err := gloop.ParallelForEach(0, 10, func(_ context.Context, i int) error {
+		called.Add(1)
+
+		if i == 3 {
+			return errFizz
+		}
+
+		return nil
+	}, gloop.WithParallelizeMaxThreads(1))
+	require.ErrorIs(t, err, errFizz)
+	require.EqualValues(t, 4, called.Load())
+}
+
+func TestParallelForEachJoinErrors(t *testing.T) {
+	errOdd := errors.New("odd index")
+
+	var called atomic.Int64
+
+	err := gloop.ParallelForEach(0, 10, func(_ context.Context, i int) error {
+		called.Add(1)
+
+		if i%2 == 1 {
+			return errOdd
+		}
+
+		return nil
+	}, gloop.WithParallelizeJoinErrors(true), gloop.WithParallelizeChunkSize(3))
+	require.ErrorIs(t, err, errOdd)
+	require.EqualValues(t, 10, called.Load())
+
+	count := 0
+
+	var walk func(error)
+	walk = func(err error) {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, err := range joined.Unwrap() {
+				walk(err)
+			}
+
+			return
+		}
+
+		count++
+	}
+	walk(err)
+
+	require.Equal(t, 5, count)
+}
+
+func TestParallelForEachCancelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	var called atomic.Int64
+
+	err := gloop.ParallelForEach(0, 10, func(_ context.Context, i int) error {
+		called.Add(1)
+
+		if i == 3 {
+			cancel()
+		}
+
+		return nil
+	}, gloop.WithParallelizeContext(ctx), gloop.WithParallelizeMaxThreads(1))
+	require.ErrorIs(t, err, context.Canceled)
+	require.EqualValues(t, 4, called.Load())
+}
@@ -2,26 +2,115 @@ package gloop
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"iter"
+	"reflect"
+	"runtime/debug"
+	"slices"
 	"sync"
+	"time"
 )
 
-// ParallelizeOptions defines configurable options for [Parallelize]
-// and [Parallelize2].
+// ParallelizeOptions defines configurable options for [Parallelize],
+// [Parallelize2], [ParallelizeErr], [ParallelizeErr2] and
+// [ParallelTransform].
 type ParallelizeOptions struct {
 	// Context is used to send a cancel signal.
 	Context context.Context
 	// MaxThreads defines the maximum number of concurrent threads
 	// allowed. If nil, there is no maximum.
 	MaxThreads *int
+	// JoinErrors indicates whether processing continues past errors in
+	// [ParallelizeErr] and [ParallelizeErr2], with all errors returned
+	// joined together. If false, processing stops at the first error,
+	// which is returned.
+	JoinErrors bool
+	// Unordered indicates whether [ParallelTransform] yields results
+	// as they complete. If false, results are yielded in the order of
+	// the sequence.
+	Unordered bool
+	// RecoverPanics indicates whether panics in the function are
+	// recovered. Each recovered panic is captured in a [*PanicError]
+	// and treated as an error returned by the function, except in
+	// [Parallelize] and [Parallelize2], where processing continues past
+	// recovered panics. If false, panics crash the program, unless
+	// PanicHandler or Repanic is set.
+	RecoverPanics bool
+	// PanicHandler is called on the calling goroutine with each
+	// recovered panic once all goroutines finish. If not nil, panics
+	// are recovered.
+	PanicHandler func(*PanicError)
+	// Repanic indicates whether the first recovered panic is panicked
+	// again on the calling goroutine once all goroutines finish. If
+	// true, panics are recovered.
+	Repanic bool
+	// RateLimit defines the maximum number of functions started per
+	// second. If zero, there is no maximum.
+	RateLimit float64
+	// RateBurst defines the maximum number of functions started
+	// without delay after a period of inactivity when RateLimit is set.
+	RateBurst int
+	// Retry defines how functions that return errors are retried in
+	// [ParallelizeErr] and [ParallelizeErr2]. If nil, functions are not
+	// retried.
+	Retry *RetryPolicy
+	// Clock is used to wait for the rate limit and between retries.
+	Clock Clock
+	// ChunkSize defines the number of values processed together on
+	// each goroutine in [ParallelFold], [ParallelFoldSlice],
+	// [ParallelFor] and [ParallelForEach]. If zero, it is chosen
+	// automatically.
+	ChunkSize int
+	// Pool is used to run functions on its workers instead of on new
+	// goroutines. If nil, each function runs on a new goroutine.
+	Pool *Pool
+	// SerializeKeys indicates whether functions for values with equal
+	// keys run one at a time, in the order of the sequence, in
+	// [Parallelize2] and [ParallelizeErr2]. Functions for values with
+	// different keys still run concurrently. Values waiting for a
+	// function with an equal key are queued without taking a thread.
+	// Keys must be comparable. Other functions ignore it.
+	SerializeKeys bool
+	// TaskTimeout defines the maximum duration of each call to the
+	// function, after which the context passed to it is cancelled. If
+	// zero, there is no maximum.
+	TaskTimeout time.Duration
+	// MaxWeight defines the maximum total weight of functions in
+	// progress. Values weighing more than MaxWeight run alone. If zero,
+	// there is no maximum.
+	MaxWeight int64
+	// Weight is a func(V) int64 returning the weight of each value of
+	// type V in the sequence when MaxWeight is set, where V must be the
+	// type of the sequence's values. Weights must not be negative. If
+	// nil, each value weighs 1. Functions listed in
+	// [WithParallelizeWeight] panic if it is set to a function of
+	// another type, and other functions panic if it is set at all.
+	Weight any
+	// Priority is a func(V) int returning the priority of each value
+	// of type V in the sequence, where V must be the type of the
+	// sequence's values. If not nil, up to PriorityBuffer values are
+	// pulled from the sequence ahead of time on a separate goroutine,
+	// and whenever a function can start, the buffered value with the
+	// highest priority is started first. Values with equal priorities
+	// are started in the order of the sequence. Functions listed in
+	// [WithParallelizePriority] panic if it is set to a function of
+	// another type, and other functions panic if it is set at all.
+	Priority any
+	// PriorityBuffer defines the maximum number of values pulled from
+	// the sequence ahead of time when Priority is set. It must be
+	// positive.
+	PriorityBuffer int
 }
 
 // ParallelizeOptionFunc is the function signature of configuration
-// helpers for [Parallelize] and [Parallelize2].
+// helpers for [Parallelize], [Parallelize2], [ParallelizeErr],
+// [ParallelizeErr2] and [ParallelTransform].
 type ParallelizeOptionFunc func(*ParallelizeOptions)
 
 // WithParallelizeContext is a helper for configuring context in
-// [Parallelize] and [Parallelize2].
+// [Parallelize], [Parallelize2], [ParallelizeErr], [ParallelizeErr2]
+// and [ParallelTransform].
 func WithParallelizeContext(ctx context.Context) ParallelizeOptionFunc {
 	return func(o *ParallelizeOptions) {
 		o.Context = ctx
@@ -29,13 +118,361 @@ func WithParallelizeContext(ctx context.Context) ParallelizeOptionFunc {
 }
 
 // WithParallelizeMaxThreads is a helper for configuring maximum number
-// of concurrent threads in [Parallelize] and [Parallelize2].
+// of concurrent threads in [Parallelize], [Parallelize2],
+// [ParallelizeErr], [ParallelizeErr2] and [ParallelTransform].
 func WithParallelizeMaxThreads(maxThreads int) ParallelizeOptionFunc {
 	return func(o *ParallelizeOptions) {
 		o.MaxThreads = &maxThreads
 	}
 }
 
+// WithParallelizeJoinErrors is a helper for configuring [ParallelizeErr]
+// and [ParallelizeErr2] to continue processing past errors and return
+// all errors joined together.
+func WithParallelizeJoinErrors(joinErrors bool) ParallelizeOptionFunc {
+	return func(o *ParallelizeOptions) {
+		o.JoinErrors = joinErrors
+	}
+}
+
+// WithParallelizeUnordered is a helper for configuring
+// [ParallelTransform] to yield results as they complete.
+func WithParallelizeUnordered(unordered bool) ParallelizeOptionFunc {
+	return func(o *ParallelizeOptions) {
+		o.Unordered = unordered
+	}
+}
+
+// WithParallelizeRecoverPanics is a helper for configuring
+// [Parallelize], [Parallelize2], [ParallelizeErr], [ParallelizeErr2]
+// and [ParallelTransform] to recover panics in the function.
+func WithParallelizeRecoverPanics(recoverPanics bool) ParallelizeOptionFunc {
+	return func(o *ParallelizeOptions) {
+		o.RecoverPanics = recoverPanics
+	}
+}
+
+// WithParallelizePanicHandler is a helper for configuring the function
+// called with recovered panics in [Parallelize], [Parallelize2],
+// [ParallelizeErr], [ParallelizeErr2] and [ParallelTransform].
+func WithParallelizePanicHandler(handler func(*PanicError)) ParallelizeOptionFunc {
+	return func(o *ParallelizeOptions) {
+		o.PanicHandler = handler
+	}
+}
+
+// WithParallelizeRepanic is a helper for configuring [Parallelize],
+// [Parallelize2], [ParallelizeErr], [ParallelizeErr2] and
+// [ParallelTransform] to panic again with the first recovered panic on
+// the calling goroutine.
+func WithParallelizeRepanic(repanic bool) ParallelizeOptionFunc {
+	return func(o *ParallelizeOptions) {
+		o.Repanic = repanic
+	}
+}
+
+// WithParallelizeRateLimit is a helper for configuring the maximum
+// number of functions started per second and the maximum burst in
+// [Parallelize], [Parallelize2], [ParallelizeErr], [ParallelizeErr2]
+// and [ParallelTransform]. The rate and burst must be positive.
+func WithParallelizeRateLimit(rate float64, burst int) ParallelizeOptionFunc {
+	return func(o *ParallelizeOptions) {
+		o.RateLimit = rate
+		o.RateBurst = burst
+	}
+}
+
+// WithParallelizeRetry is a helper for configuring how functions that
+// return errors are retried in [ParallelizeErr] and [ParallelizeErr2].
+func WithParallelizeRetry(policy RetryPolicy) ParallelizeOptionFunc {
+	return func(o *ParallelizeOptions) {
+		o.Retry = &policy
+	}
+}
+
+// WithParallelizeClock is a helper for configuring the clock in
+// [Parallelize], [Parallelize2], [ParallelizeErr], [ParallelizeErr2]
+// and [ParallelTransform].
+func WithParallelizeClock(clock Clock) ParallelizeOptionFunc {
+	return func(o *ParallelizeOptions) {
+		o.Clock = clock
+	}
+}
+
+// WithParallelizeChunkSize is a helper for configuring the number of
+// values processed together on each goroutine in [ParallelFold],
+// [ParallelFoldSlice], [ParallelFor] and [ParallelForEach].
+func WithParallelizeChunkSize(chunkSize int) ParallelizeOptionFunc {
+	return func(o *ParallelizeOptions) {
+		o.ChunkSize = chunkSize
+	}
+}
+
+// WithParallelizePool is a helper for configuring the pool running
+// functions in [Parallelize], [Parallelize2], [ParallelizeErr],
+// [ParallelizeErr2] and [ParallelTransform].
+func WithParallelizePool(pool *Pool) ParallelizeOptionFunc {
+	return func(o *ParallelizeOptions) {
+		o.Pool = pool
+	}
+}
+
+// WithParallelizeSerializeKeys is a helper for configuring
+// [Parallelize2] and [ParallelizeErr2] to run functions for values with
+// equal keys one at a time, in the order of the sequence.
+func WithParallelizeSerializeKeys(serializeKeys bool) ParallelizeOptionFunc {
+	return func(o *ParallelizeOptions) {
+		o.SerializeKeys = serializeKeys
+	}
+}
+
+// WithParallelizeTaskTimeout is a helper for configuring the maximum
+// duration of each call to the function in [ParallelizeContext],
+// [ParallelizeContext2], [ParallelizeErr] and [ParallelizeErr2]. The
+// timeout must not be negative.
+func WithParallelizeTaskTimeout(timeout time.Duration) ParallelizeOptionFunc {
+	return func(o *ParallelizeOptions) {
+		o.TaskTimeout = timeout
+	}
+}
+
+// WithParallelizeWeight is a helper for configuring the maximum total
+// weight of functions in progress and the weight of each value in
+// [Parallelize], [Parallelize2], [ParallelizeContext],
+// [ParallelizeContext2], [ParallelizeErr], [ParallelizeErr2] and
+// [ParallelTransform], and of each task in [DAG]. The weight function
+// is called with the values of the sequence, or with the tasks in
+// [DAG], so V must be their type. Functions that split their input
+// into chunks of their own, such as [ParallelFold], [ParallelFor] and
+// [ParallelSort], panic if a weight function is set.
+func WithParallelizeWeight[V any](maxWeight int64, weight func(V) int64) ParallelizeOptionFunc {
+	return func(o *ParallelizeOptions) {
+		o.MaxWeight = maxWeight
+		o.Weight = nil
+
+		if weight != nil {
+			o.Weight = weight
+		}
+	}
+}
+
+// WithParallelizePriority is a helper for configuring the priority of
+// each value and the number of values pulled ahead of time in
+// [Parallelize], [Parallelize2], [ParallelizeContext],
+// [ParallelizeContext2], [ParallelizeErr], [ParallelizeErr2] and
+// [ParallelTransform], and of each task in [DAG]. The priority function
+// is called with the values of the sequence, or with the tasks in
+// [DAG], so V must be their type. Functions that split their input
+// into chunks of their own, such as [ParallelFold], [ParallelFor] and
+// [ParallelSort], panic if a priority function is set.
+func WithParallelizePriority[V any](priority func(V) int, buffer int) ParallelizeOptionFunc {
+	return func(o *ParallelizeOptions) {
+		o.Priority = nil
+		o.PriorityBuffer = buffer
+
+		if priority != nil {
+			o.Priority = priority
+		}
+	}
+}
+
+// parallelizeValueFuncs returns the weight and priority functions of
+// the options, and panics if they do not take values of a given type.
+func parallelizeValueFuncs[V any](options ParallelizeOptions) (func(V) int64, func(V) int) {
+	weight, ok := options.Weight.(func(V) int64)
+	if options.Weight != nil && !ok {
+		panic("weight function must take the sequence's value type")
+	}
+
+	priority, ok := options.Priority.(func(V) int)
+	if options.Priority != nil && !ok {
+		panic("priority function must take the sequence's value type")
+	}
+
+	return weight, priority
+}
+
+// validateNoValueFuncs panics if weight or priority functions are
+// configured, for functions that parallelize over chunks or tasks of
+// their own rather than the values of the caller's sequence.
+func validateNoValueFuncs(options ParallelizeOptions) {
+	if options.Weight != nil {
+		panic("weight function is not supported")
+	}
+
+	if options.Priority != nil {
+		panic("priority function is not supported")
+	}
+}
+
+// newParallelizeOptions returns the default [ParallelizeOptions] with
+// the given configuration helpers applied.
+func newParallelizeOptions(opts ...ParallelizeOptionFunc) ParallelizeOptions {
+	options := ParallelizeOptions{
+		Context:        context.Background(),
+		MaxThreads:     nil,
+		JoinErrors:     false,
+		Unordered:      false,
+		RecoverPanics:  false,
+		PanicHandler:   nil,
+		Repanic:        false,
+		RateLimit:      0,
+		RateBurst:      0,
+		Retry:          nil,
+		Clock:          SystemClock{},
+		ChunkSize:      0,
+		Pool:           nil,
+		SerializeKeys:  false,
+		TaskTimeout:    0,
+		MaxWeight:      0,
+		Weight:         nil,
+		Priority:       nil,
+		PriorityBuffer: 0,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.Context == nil {
+		options.Context = context.Background()
+	}
+
+	return options
+}
+
+// PanicError is a panic recovered from a function running on a
+// separate goroutine.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the goroutine that panicked.
+	Stack []byte
+}
+
+// Error returns the error message.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("recovered panic: %v", e.Value)
+}
+
+// Unwrap returns the value passed to panic if it is an error, or nil
+// otherwise.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+
+	return err
+}
+
+// recoverParallelizePanic runs a given function, recovering and
+// returning a panic if configured to do so.
+func recoverParallelizePanic(options ParallelizeOptions, f func()) *PanicError {
+	if !options.RecoverPanics && options.PanicHandler == nil && !options.Repanic {
+		f()
+
+		return nil
+	}
+
+	var panicErr *PanicError
+
+	func() {
+		defer func() {
+			if value := recover(); value != nil {
+				panicErr = &PanicError{
+					Value: value,
+					Stack: debug.Stack(),
+				}
+			}
+		}()
+
+		f()
+	}()
+
+	return panicErr
+}
+
+// reportParallelizePanics passes recovered panics to the panic
+// handler, and panics again with the first one if configured to do so.
+func reportParallelizePanics(options ParallelizeOptions, panicErrs []*PanicError) {
+	if options.PanicHandler != nil {
+		for _, panicErr := range panicErrs {
+			options.PanicHandler(panicErr)
+		}
+	}
+
+	if options.Repanic && len(panicErrs) > 0 {
+		panic(panicErrs[0])
+	}
+}
+
+// startRetryPolicy validates a retry policy, if any, and resets its
+// report.
+func startRetryPolicy(policy *RetryPolicy) {
+	if policy == nil {
+		return
+	}
+
+	if policy.MaxAttempts <= 0 {
+		panic("max attempts must be positive")
+	}
+
+	if policy.Report != nil {
+		policy.Report.reset()
+	}
+}
+
+// runParallelizeFunc runs a given function for the value at a given
+// index, retrying it, limiting the duration of each call and recovering
+// panics if configured to do so. It returns the recovered panic, if
+// any, and the last error.
+func runParallelizeFunc(
+	ctx context.Context,
+	options ParallelizeOptions,
+	index int,
+	f func(context.Context) error,
+) (*PanicError, error) {
+	var err error
+	var panicErr *PanicError
+	attempts := 0
+
+	for {
+		attempts++
+
+		panicErr = recoverParallelizePanic(options, func() {
+			callCtx := ctx
+			if options.TaskTimeout != 0 {
+				var cancel context.CancelFunc
+				callCtx, cancel = context.WithTimeout(ctx, options.TaskTimeout)
+				defer cancel()
+			}
+
+			err = f(callCtx)
+		})
+		if panicErr != nil {
+			err = panicErr
+
+			break
+		}
+
+		if err == nil || options.Retry == nil || !options.Retry.retryable(err, attempts) {
+			break
+		}
+
+		if !sleep(ctx, options.Clock, options.Retry.backoff(attempts)) {
+			break
+		}
+	}
+
+	if options.Retry != nil && options.Retry.Report != nil {
+		options.Retry.Report.add(RetryResult{
+			Index:    index,
+			Attempts: attempts,
+			Err:      err,
+		})
+	}
+
+	return panicErr, err
+}
+
 // ParallelizeFunc is the function signature of the function to be
 // parallelized in [Parallelize].
 type ParallelizeFunc[V any] func(V)
@@ -57,25 +494,135 @@ func Parallelize[V any](
 type Parallelize2Func[K, V any] func(K, V)
 
 // Parallelize2 runs a function on each value in an [iter.Seq2]
-// sequence on separate goroutines.
+// sequence on separate goroutines. Once the context is cancelled, no
+// further values are pulled from the sequence. If configured to
+// serialize keys, functions for values with equal keys run one at a
+// time, in the order of the sequence.
 func Parallelize2[K, V any](
 	seq iter.Seq2[K, V],
 	f Parallelize2Func[K, V],
 	opts ...ParallelizeOptionFunc,
 ) {
-	options := ParallelizeOptions{
-		Context:    context.Background(),
-		MaxThreads: nil,
-	}
+	opts = append(slices.Clone(opts), WithParallelizeJoinErrors(true))
 
-	for _, opt := range opts {
-		opt(&options)
-	}
+	_ = ParallelizeErr2(seq, func(_ context.Context, key K, value V) error {
+		f(key, value)
 
-	ctx := context.Background()
-	if options.Context != nil {
-		ctx = options.Context
-	}
+		return nil
+	}, opts...)
+}
+
+// ParallelizeContextFunc is the function signature of the function to
+// be parallelized in [ParallelizeContext].
+type ParallelizeContextFunc[V any] func(context.Context, V)
+
+// ParallelizeContext runs a function on each value in an [iter.Seq]
+// sequence on separate goroutines, passing it a context that is
+// cancelled once the parent context is cancelled or, if configured
+// with a task timeout, once the timeout elapses. Once the parent
+// context is cancelled, no further values are pulled from the
+// sequence, even while waiting for the sequence to yield its next
+// value, as in [ParallelizeContext2].
+func ParallelizeContext[V any](
+	seq iter.Seq[V],
+	f ParallelizeContextFunc[V],
+	opts ...ParallelizeOptionFunc,
+) {
+	ParallelizeContext2(Enumerate(seq), func(ctx context.Context, _ int, value V) {
+		f(ctx, value)
+	}, opts...)
+}
+
+// ParallelizeContext2Func is the function signature of the function to
+// be parallelized in [ParallelizeContext2].
+type ParallelizeContext2Func[K, V any] func(context.Context, K, V)
+
+// ParallelizeContext2 runs a function on each value in an [iter.Seq2]
+// sequence on separate goroutines, passing it a context that is
+// cancelled once the parent context is cancelled or, if configured
+// with a task timeout, once the timeout elapses. Once the parent
+// context is cancelled, no further values are pulled from the
+// sequence, even while waiting for the sequence to yield its next
+// value, so that sequences that wait for values, such as [Channel], can
+// be stopped. To do so, the sequence is pulled on a separate goroutine,
+// which returns once the sequence yields its next value or ends. If
+// configured to serialize keys, functions for values with equal keys
+// run one at a time, in the order of the sequence.
+func ParallelizeContext2[K, V any](
+	seq iter.Seq2[K, V],
+	f ParallelizeContext2Func[K, V],
+	opts ...ParallelizeOptionFunc,
+) {
+	options := newParallelizeOptions(opts...)
+	opts = append(slices.Clone(opts), WithParallelizeJoinErrors(true))
+
+	_ = ParallelizeErr2(contextSeq2(options.Context, seq), func(ctx context.Context, key K, value V) error {
+		f(ctx, key, value)
+
+		return nil
+	}, opts...)
+}
+
+// ParallelizeErrFunc is the function signature of the function to be
+// parallelized in [ParallelizeErr].
+type ParallelizeErrFunc[V any] func(context.Context, V) error
+
+// ParallelizeErr runs a function on each value in an [iter.Seq]
+// sequence on separate goroutines, passing it a context that is
+// cancelled once processing stops. By default, processing stops at the
+// first error, after which no further values are pulled from the
+// sequence, and the first error is returned. If configured to join
+// errors, all errors are returned joined together. If configured with
+// a [RetryPolicy], functions that return errors are retried first. If
+// the context is cancelled and no function returns an error, the
+// context's error is returned.
+func ParallelizeErr[V any](
+	seq iter.Seq[V],
+	f ParallelizeErrFunc[V],
+	opts ...ParallelizeOptionFunc,
+) error {
+	return ParallelizeErr2(Enumerate(seq), func(ctx context.Context, _ int, value V) error {
+		return f(ctx, value)
+	}, opts...)
+}
+
+// serializedCall is a call to the function parallelized in
+// [ParallelizeErr2] for a value with a given weight, queued while the
+// function for a value with an equal key is in progress.
+type serializedCall struct {
+	weight int64
+	call   func()
+}
+
+// ParallelizeErr2Func is the function signature of the function to be
+// parallelized in [ParallelizeErr2].
+type ParallelizeErr2Func[K, V any] func(context.Context, K, V) error
+
+// ParallelizeErr2 runs a function on each value in an [iter.Seq2]
+// sequence on separate goroutines, passing it a context that is
+// cancelled once processing stops. By default, processing stops at the
+// first error, after which no further values are pulled from the
+// sequence, and the first error is returned. If configured to join
+// errors, all errors are returned joined together. If configured with
+// a [RetryPolicy], functions that return errors are retried first. If
+// configured to serialize keys, functions for values with equal keys
+// run one at a time, in the order of the sequence. If configured with a
+// task timeout, the context passed to each call is cancelled once the
+// timeout elapses. If configured with a maximum weight, functions only
+// start while the total weight of functions in progress stays within
+// it. If configured with a priority function, values are pulled ahead
+// of time, and the buffered value with the highest priority starts
+// first. If the context is cancelled and no function returns an error,
+// the context's error is returned.
+func ParallelizeErr2[K, V any](
+	seq iter.Seq2[K, V],
+	f ParallelizeErr2Func[K, V],
+	opts ...ParallelizeOptionFunc,
+) error {
+	options := newParallelizeOptions(opts...)
+
+	ctx, cancel := context.WithCancel(options.Context)
+	defer cancel()
 
 	var semaphore chan struct{}
 	if options.MaxThreads != nil {
@@ -83,34 +630,331 @@ func Parallelize2[K, V any](
 		defer close(semaphore)
 	}
 
-	var wg sync.WaitGroup
+	startRetryPolicy(options.Retry)
+
+	if options.TaskTimeout < 0 {
+		panic("task timeout must not be negative")
+	}
+
+	var bucket *tokenBucket
+	if options.RateLimit != 0 {
+		validateTokenBucket(options.RateLimit, options.RateBurst)
+		bucket = newTokenBucket(options.RateLimit, options.RateBurst, options.Clock)
+	}
+
+	if options.MaxWeight < 0 {
+		panic("max weight must not be negative")
+	}
+
+	var weights *weightedSemaphore
+	if options.MaxWeight != 0 {
+		weights = newWeightedSemaphore(options.MaxWeight)
+	}
+
+	weightFunc, priorityFunc := parallelizeValueFuncs[V](options)
+
+	if options.Priority != nil && options.PriorityBuffer <= 0 {
+		panic("priority buffer must be positive")
+	}
+
+	// checkKeys indicates whether each key must be checked for
+	// comparability, as keys of interface types may hold values of any
+	// type.
+	checkKeys := false
+	if options.SerializeKeys {
+		keyType := reflect.TypeFor[K]()
+		if !keyType.Comparable() {
+			panic("keys must be comparable")
+		}
+
+		checkKeys = keyType.Kind() == reflect.Interface
+	}
+
+	acquireThread := func() bool {
+		if ctx.Err() != nil {
+			return false
+		}
+
+		if semaphore != nil {
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				return false
+			}
+		}
 
-	for key, value := range seq {
+		return true
+	}
+
+	releaseThread := func() {
 		if semaphore != nil {
-			semaphore <- struct{}{}
+			<-semaphore
+		}
+	}
+
+	weigh := func(value V) int64 {
+		if weights == nil || weightFunc == nil {
+			return 1
+		}
+
+		weight := weightFunc(value)
+		if weight < 0 {
+			panic("weight must not be negative")
+		}
+
+		return weight
+	}
+
+	// limitsMu makes sure the weights and the rate limit are only
+	// acquired from one goroutine at a time, as queued functions for
+	// serialized keys acquire them on the goroutine of the previous
+	// function.
+	var limitsMu sync.Mutex
+
+	// acquireLimits waits until a function for a value with a given
+	// weight fits within the weight and rate limits.
+	acquireLimits := func(weight int64) bool {
+		limitsMu.Lock()
+		defer limitsMu.Unlock()
+
+		if weights != nil && !weights.acquire(ctx, weight) {
+			return false
+		}
+
+		if bucket != nil && !bucket.wait(ctx) {
+			if weights != nil {
+				weights.release(weight)
+			}
+
+			return false
+		}
+
+		return true
+	}
+
+	// acquire waits until a function for a value with a given weight
+	// can start. If values are prioritized, the thread is acquired
+	// before the value is picked instead.
+	acquire := func(weight int64) bool {
+		if options.Priority == nil && !acquireThread() {
+			return false
+		}
+
+		if !acquireLimits(weight) {
+			releaseThread()
+
+			return false
+		}
+
+		return true
+	}
+
+	values := seq
+	if options.Priority != nil {
+		values = prioritySeq2(ctx, seq, priorityFunc, options.PriorityBuffer, acquireThread, releaseThread)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	errs := make([]error, 0)
+	panicErrs := make([]*PanicError, 0)
+
+	// queues holds the values waiting for the function in progress for
+	// each key with a function in progress, in the order of the
+	// sequence.
+	queues := make(map[any][]serializedCall)
+
+	index := -1
+
+	for key, value := range values {
+		if checkKeys && !reflect.ValueOf(&key).Elem().Comparable() {
+			panic("keys must be comparable")
+		}
+
+		index++
+
+		i := index
+		next := serializedCall{
+			weight: weigh(value),
+			call: func() {
+				if ctx.Err() != nil {
+					return
+				}
+
+				panicErr, err := runParallelizeFunc(ctx, options, i, func(ctx context.Context) error {
+					return f(ctx, key, value)
+				})
+				if err == nil {
+					return
+				}
+
+				mu.Lock()
+				errs = append(errs, err)
+				if panicErr != nil {
+					panicErrs = append(panicErrs, panicErr)
+				}
+				mu.Unlock()
+
+				if !options.JoinErrors {
+					cancel()
+				}
+			},
+		}
+
+		if options.SerializeKeys {
+			mu.Lock()
+			queue, ok := queues[key]
+			if ok {
+				queues[key] = append(queue, next)
+			} else {
+				queues[key] = nil
+			}
+			mu.Unlock()
+
+			if ok {
+				// the value's function runs on the goroutine of the
+				// function in progress for its key once it finishes.
+				if options.Priority != nil {
+					releaseThread()
+				}
+
+				if ctx.Err() != nil {
+					break
+				}
+
+				continue
+			}
+		}
+
+		if !acquire(next.weight) {
+			break
 		}
 
 		wg.Add(1)
 
-		go func(k K, v V) {
+		task := func() {
 			defer wg.Done()
 
-			if semaphore != nil {
-				defer func(s <-chan struct{}) {
-					<-s
-				}(semaphore)
+			defer releaseThread()
+
+			for {
+				next.call()
+
+				if weights != nil {
+					weights.release(next.weight)
+				}
+
+				if !options.SerializeKeys {
+					return
+				}
+
+				mu.Lock()
+				queue := queues[key]
+				if len(queue) == 0 || ctx.Err() != nil {
+					delete(queues, key)
+					mu.Unlock()
+
+					return
+				}
+
+				next = queue[0]
+				queues[key] = queue[1:]
+				mu.Unlock()
+
+				if !acquireLimits(next.weight) {
+					mu.Lock()
+					delete(queues, key)
+					mu.Unlock()
+
+					return
+				}
 			}
+		}
+
+		if options.Pool == nil {
+			go task()
+
+			continue
+		}
+
+		if !options.Pool.submit(ctx, task) {
+			// the context is cancelled, so the task only releases its
+			// resources.
+			task()
+
+			break
+		}
+	}
+
+	wg.Wait()
+
+	reportParallelizePanics(options, panicErrs)
+
+	if len(errs) == 0 {
+		return options.Context.Err()
+	}
+
+	if options.JoinErrors {
+		return errors.Join(errs...)
+	}
+
+	return errs[0]
+}
+
+// contextSeq allows looping over an [iter.Seq] sequence until a given
+// context is cancelled, even while waiting for the sequence to yield
+// its next value. It is the [iter.Seq] counterpart of contextSeq2.
+func contextSeq[V any](ctx context.Context, seq iter.Seq[V]) iter.Seq[V] {
+	return Values(contextSeq2(ctx, Enumerate(seq)))
+}
+
+// contextSeq2 allows looping over an [iter.Seq2] sequence until a given
+// context is cancelled, even while waiting for the sequence to yield
+// its next value. If the context can be cancelled, the sequence is
+// pulled on a separate goroutine, one value at a time as values are
+// consumed. If looping ends while waiting for the sequence, that
+// goroutine returns once the sequence yields its next value or ends.
+func contextSeq2[K, V any](ctx context.Context, seq iter.Seq2[K, V]) iter.Seq2[K, V] {
+	if ctx.Done() == nil {
+		return seq
+	}
+
+	return func(yield func(K, V) bool) {
+		next := make(chan struct{})
+		pairs := make(chan KeyValuePair[K, V], 1)
+
+		go func() {
+			defer close(pairs)
+
+			pull, stop := iter.Pull2(seq)
+			defer stop()
+
+			for range next {
+				key, value, ok := pull()
+				if !ok {
+					return
+				}
+
+				pairs <- KeyValuePair[K, V]{
+					Key:   key,
+					Value: value,
+				}
+			}
+		}()
+
+		defer close(next)
+
+		for ctx.Err() == nil {
+			next <- struct{}{}
 
 			select {
+			case pair, ok := <-pairs:
+				if !ok || !yield(pair.Key, pair.Value) {
+					return
+				}
 			case <-ctx.Done():
-				return
-			default:
-				f(k, v)
-
 				return
 			}
-		}(key, value)
+		}
 	}
-
-	wg.Wait()
 }
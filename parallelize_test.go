@@ -2,6 +2,11 @@ package gloop_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"runtime"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -280,3 +285,1081 @@ func TestParallelize2SingleThreaded(t *testing.T) {
 
 	require.EqualValues(t, 1, maxConcurrentCallers.Load())
 }
+
+func TestWithParallelizeJoinErrors(t *testing.T) {
+	options := gloop.ParallelizeOptions{}
+	gloop.WithParallelizeJoinErrors(true)(&options)
+
+	require.True(t, options.JoinErrors)
+}
+
+func TestParallelizeErr(t *testing.T) {
+	values := []int{3, 4, 5}
+
+	var sum atomic.Int64
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Slice(values), func(_ context.Context, v int) error {
+			sum.Add(int64(v))
+
+			return nil
+		})
+	}()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 12, sum.Load())
+}
+
+func TestParallelizeErr2(t *testing.T) {
+	m := map[string]int{
+		"Fizz": 3,
+		"Buzz": 1,
+		"Bazz": 4,
+	}
+	errFizz := errors.New("fizz error")
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr2(gloop.Map(m), func(_ context.Context, k string, _ int) error {
+			if k == "Fizz" {
+				return errFizz
+			}
+
+			return nil
+		})
+	}()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, errFizz)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestParallelizeErrStopsPulling(t *testing.T) {
+	errFail := errors.New("fail")
+
+	var (
+		pulled atomic.Int64
+		called atomic.Int64
+	)
+
+	seq := func(yield func(int) bool) {
+		for i := range 10 {
+			pulled.Add(1)
+
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(seq, func(_ context.Context, v int) error {
+			called.Add(1)
+
+			if v == 1 {
+				return errFail
+			}
+
+			return nil
+		}, gloop.WithParallelizeMaxThreads(1))
+	}()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, errFail)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 2, called.Load())
+	require.LessOrEqual(t, pulled.Load(), int64(4))
+}
+
+func TestParallelizeErrCancelsContext(t *testing.T) {
+	values := []string{"Fizz", "Buzz"}
+	errBuzz := errors.New("buzz error")
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Slice(values), func(ctx context.Context, v string) error {
+			if v == "Buzz" {
+				return errBuzz
+			}
+
+			<-ctx.Done()
+
+			return ctx.Err()
+		})
+	}()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, errBuzz)
+		require.NotErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestParallelizeErrJoinErrors(t *testing.T) {
+	values := []int{3, 4, 5, 6}
+	errOdd := errors.New("odd value")
+
+	var called atomic.Int64
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Slice(values), func(ctx context.Context, v int) error {
+			called.Add(1)
+
+			if v%2 == 1 {
+				return fmt.Errorf("%d: %w", v, errOdd)
+			}
+
+			return ctx.Err()
+		}, gloop.WithParallelizeJoinErrors(true), gloop.WithParallelizeMaxThreads(1))
+	}()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, errOdd)
+		require.ErrorContains(t, err, "3: odd value")
+		require.ErrorContains(t, err, "5: odd value")
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, len(values), called.Load())
+}
+
+func TestParallelizeErrCancelContext(t *testing.T) {
+	values := []string{"Fizz"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var called atomic.Bool
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Slice(values), func(_ context.Context, _ string) error {
+			called.Store(true)
+
+			return nil
+		}, gloop.WithParallelizeContext(ctx))
+	}()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.False(t, called.Load())
+}
+
+func TestParallelizeCancelContextStopsPulling(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	var pulled atomic.Int64
+
+	seq := func(yield func(int) bool) {
+		for i := range 10 {
+			pulled.Add(1)
+
+			if i == 2 {
+				cancel()
+			}
+
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	done := make(chan struct{}, 1)
+	go func() {
+		gloop.Parallelize(seq, func(_ int) {}, gloop.WithParallelizeContext(ctx))
+		done <- struct{}{}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 3, pulled.Load())
+}
+
+func TestParallelizeErrNilContext(t *testing.T) {
+	values := []int{3, 4, 5}
+
+	var (
+		ctx    context.Context
+		called atomic.Int64
+	)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Slice(values), func(_ context.Context, _ int) error {
+			called.Add(1)
+
+			return nil
+		}, gloop.WithParallelizeContext(ctx))
+	}()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, len(values), called.Load())
+}
+
+func TestWithParallelizeRecoverPanics(t *testing.T) {
+	options := gloop.ParallelizeOptions{}
+	gloop.WithParallelizeRecoverPanics(true)(&options)
+
+	require.True(t, options.RecoverPanics)
+}
+
+func TestWithParallelizePanicHandler(t *testing.T) {
+	called := false
+	options := gloop.ParallelizeOptions{}
+	gloop.WithParallelizePanicHandler(func(_ *gloop.PanicError) {
+		called = true
+	})(&options)
+
+	require.NotNil(t, options.PanicHandler)
+
+	options.PanicHandler(nil)
+	require.True(t, called)
+}
+
+func TestWithParallelizeRepanic(t *testing.T) {
+	options := gloop.ParallelizeOptions{}
+	gloop.WithParallelizeRepanic(true)(&options)
+
+	require.True(t, options.Repanic)
+}
+
+func TestPanicError(t *testing.T) {
+	errFizz := errors.New("fizz error")
+
+	testcases := map[string]struct {
+		value      any
+		wantString string
+		wantErr    error
+	}{
+		"String value": {
+			value:      "Fizz",
+			wantString: "recovered panic: Fizz",
+			wantErr:    nil,
+		},
+		"Error value": {
+			value:      errFizz,
+			wantString: "recovered panic: fizz error",
+			wantErr:    errFizz,
+		},
+	}
+
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := &gloop.PanicError{Value: testcase.value}
+			require.Equal(t, testcase.wantString, err.Error())
+			require.Equal(t, testcase.wantErr, err.Unwrap())
+		})
+	}
+}
+
+func TestParallelizeRecoverPanics(t *testing.T) {
+	values := []string{"Fizz", "Buzz", "Bazz"}
+
+	var called atomic.Int64
+
+	done := make(chan struct{}, 1)
+	go func() {
+		gloop.Parallelize(gloop.Slice(values), func(v string) {
+			called.Add(1)
+
+			if v == "Buzz" {
+				panic(v)
+			}
+		}, gloop.WithParallelizeRecoverPanics(true))
+		done <- struct{}{}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, len(values), called.Load())
+}
+
+func TestParallelizeErrRecoverPanics(t *testing.T) {
+	values := []string{"Fizz", "Buzz"}
+	errBuzz := errors.New("buzz error")
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Slice(values), func(_ context.Context, v string) error {
+			if v == "Buzz" {
+				panic(errBuzz)
+			}
+
+			return nil
+		}, gloop.WithParallelizeRecoverPanics(true))
+	}()
+
+	select {
+	case err := <-done:
+		var panicErr *gloop.PanicError
+		require.ErrorAs(t, err, &panicErr)
+		require.Equal(t, errBuzz, panicErr.Value)
+		require.Contains(t, string(panicErr.Stack), "parallelize_test.go")
+		require.ErrorIs(t, err, errBuzz)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestParallelizeErrPanicHandler(t *testing.T) {
+	values := []string{"Fizz", "Buzz", "Bazz"}
+	errFizz := errors.New("fizz error")
+
+	panicValues := make([]any, 0)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Slice(values), func(_ context.Context, v string) error {
+			if v == "Fizz" {
+				return errFizz
+			}
+
+			panic(v)
+		},
+			gloop.WithParallelizeJoinErrors(true),
+			gloop.WithParallelizeMaxThreads(1),
+			gloop.WithParallelizePanicHandler(func(err *gloop.PanicError) {
+				panicValues = append(panicValues, err.Value)
+			}),
+		)
+	}()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, errFizz)
+		require.ErrorContains(t, err, "recovered panic: Buzz")
+		require.ErrorContains(t, err, "recovered panic: Bazz")
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.Equal(t, []any{"Buzz", "Bazz"}, panicValues)
+}
+
+func TestParallelize2Repanic(t *testing.T) {
+	m := map[string]int{
+		"Fizz": 3,
+		"Buzz": 1,
+	}
+
+	var called atomic.Int64
+
+	done := make(chan any, 1)
+	go func() {
+		defer func() {
+			done <- recover()
+		}()
+
+		gloop.Parallelize2(gloop.Map(m), func(k string, _ int) {
+			called.Add(1)
+
+			if k == "Buzz" {
+				panic(k)
+			}
+		}, gloop.WithParallelizeRepanic(true))
+	}()
+
+	select {
+	case value := <-done:
+		panicErr, ok := value.(*gloop.PanicError)
+		require.True(t, ok)
+		require.Equal(t, "Buzz", panicErr.Value)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, len(m), called.Load())
+}
+
+func TestWithParallelizeRateLimit(t *testing.T) {
+	options := gloop.ParallelizeOptions{}
+	gloop.WithParallelizeRateLimit(10, 3)(&options)
+
+	require.InDelta(t, 10, options.RateLimit, 0)
+	require.Equal(t, 3, options.RateBurst)
+}
+
+func TestWithParallelizeClock(t *testing.T) {
+	clock := newFakeClock(time.Now())
+	options := gloop.ParallelizeOptions{}
+	gloop.WithParallelizeClock(clock)(&options)
+
+	require.Equal(t, clock, options.Clock)
+}
+
+func TestParallelizeRateLimit(t *testing.T) {
+	clock := newFakeClock(time.Now())
+	values := []int{3, 1, 4, 1}
+	started := make(chan int, len(values))
+
+	done := make(chan struct{}, 1)
+	go func() {
+		gloop.Parallelize(gloop.Slice(values), func(v int) {
+			started <- v
+		}, gloop.WithParallelizeRateLimit(10, 2), gloop.WithParallelizeClock(clock))
+		done <- struct{}{}
+	}()
+
+	receive := func(n int) {
+		for range n {
+			select {
+			case <-started:
+			case <-time.After(time.Second * 10):
+				t.Fatal("start took too long")
+			}
+		}
+	}
+
+	receive(2)
+
+	for range 2 {
+		clock.WaitForAfter(t)
+		require.Empty(t, started)
+		clock.Advance(time.Millisecond * 100)
+		receive(1)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestParallelizeErrRateLimitCancelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	clock := newFakeClock(time.Now())
+	values := []int{3, 1, 4}
+
+	var called atomic.Int64
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Slice(values), func(_ context.Context, _ int) error {
+			called.Add(1)
+
+			return nil
+		},
+			gloop.WithParallelizeContext(ctx),
+			gloop.WithParallelizeMaxThreads(1),
+			gloop.WithParallelizeRateLimit(1, 1),
+			gloop.WithParallelizeClock(clock),
+		)
+	}()
+
+	clock.WaitForAfter(t)
+	cancel()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 1, called.Load())
+}
+
+func TestParallelizeErrRateLimitInvalidPanics(t *testing.T) {
+	require.Panics(t, func() {
+		_ = gloop.ParallelizeErr(gloop.Slice([]int{3}), func(_ context.Context, _ int) error {
+			return nil
+		}, gloop.WithParallelizeRateLimit(10, 0))
+	})
+}
+
+func TestWithParallelizeSerializeKeys(t *testing.T) {
+	options := gloop.ParallelizeOptions{}
+	gloop.WithParallelizeSerializeKeys(true)(&options)
+
+	require.True(t, options.SerializeKeys)
+}
+
+// accountEvents returns an [iter.Seq2] sequence of interleaved events
+// for a given number of accounts, numbered in order within each
+// account.
+func accountEvents(accounts int, events int) iter.Seq2[string, int] {
+	return func(yield func(string, int) bool) {
+		for event := range events {
+			for account := range accounts {
+				if !yield(fmt.Sprintf("account-%d", account), event) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func TestParallelize2SerializeKeys(t *testing.T) {
+	accounts := 5
+	events := 20
+
+	testcases := map[string]struct {
+		opts           []gloop.ParallelizeOptionFunc
+		maxConcurrency int64
+	}{
+		"No max threads": {
+			opts:           nil,
+			maxConcurrency: int64(accounts),
+		},
+		"Max threads": {
+			opts: []gloop.ParallelizeOptionFunc{
+				gloop.WithParallelizeMaxThreads(3),
+			},
+			maxConcurrency: 3,
+		},
+	}
+
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu                   sync.Mutex
+				concurrentCallers    atomic.Int64
+				maxConcurrentCallers atomic.Int64
+			)
+
+			gotEvents := make(map[string][]int)
+			running := make(map[string]bool)
+			overlapped := false
+
+			opts := append(testcase.opts, gloop.WithParallelizeSerializeKeys(true))
+
+			done := make(chan struct{}, 1)
+
+			go func() {
+				gloop.Parallelize2(accountEvents(accounts, events), func(account string, event int) {
+					n := concurrentCallers.Add(1)
+					defer concurrentCallers.Add(-1)
+
+					for {
+						m := maxConcurrentCallers.Load()
+						if n <= m || maxConcurrentCallers.CompareAndSwap(m, n) {
+							break
+						}
+					}
+
+					mu.Lock()
+					if running[account] {
+						overlapped = true
+					}
+					running[account] = true
+					mu.Unlock()
+
+					time.Sleep(time.Millisecond)
+
+					mu.Lock()
+					running[account] = false
+					gotEvents[account] = append(gotEvents[account], event)
+					mu.Unlock()
+				}, opts...)
+				done <- struct{}{}
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(time.Second * 10):
+				t.Fatal("done signal took too long")
+			}
+
+			require.False(t, overlapped)
+			require.LessOrEqual(t, maxConcurrentCallers.Load(), testcase.maxConcurrency)
+			require.Greater(t, maxConcurrentCallers.Load(), int64(1))
+			require.Len(t, gotEvents, accounts)
+
+			for _, accountEvents := range gotEvents {
+				require.Equal(t, gloop.ToSlice(gloop.Interval(0, events, 1)), accountEvents)
+			}
+		})
+	}
+}
+
+func TestParallelizeErr2SerializeKeysError(t *testing.T) {
+	errFizz := errors.New("fizz error")
+
+	var mu sync.Mutex
+
+	gotEvents := make(map[string][]int)
+
+	err := gloop.ParallelizeErr2(accountEvents(2, 10), func(_ context.Context, account string, event int) error {
+		mu.Lock()
+		gotEvents[account] = append(gotEvents[account], event)
+		mu.Unlock()
+
+		if account == "account-0" && event == 3 {
+			return errFizz
+		}
+
+		return nil
+	}, gloop.WithParallelizeSerializeKeys(true), gloop.WithParallelizeMaxThreads(4))
+	require.ErrorIs(t, err, errFizz)
+	require.Equal(t, []int{0, 1, 2, 3}, gotEvents["account-0"])
+}
+
+func TestParallelizeErr2SerializeKeysCancelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	var called atomic.Int64
+
+	err := gloop.ParallelizeErr2(accountEvents(1, 10), func(_ context.Context, _ string, event int) error {
+		called.Add(1)
+
+		if event == 2 {
+			cancel()
+		}
+
+		return nil
+	}, gloop.WithParallelizeContext(ctx), gloop.WithParallelizeSerializeKeys(true))
+	require.ErrorIs(t, err, context.Canceled)
+	require.EqualValues(t, 3, called.Load())
+}
+
+func TestParallelizeErr2SerializeKeysCancelContextWhileQueued(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	cancelled := make(chan struct{})
+	exhausted := make(chan struct{})
+
+	seq := func(yield func(string, int) bool) {
+		defer close(exhausted)
+
+		if !yield("Fizz", 0) {
+			return
+		}
+
+		<-cancelled
+
+		_ = yield("Fizz", 1) && yield("Fizz", 2)
+	}
+
+	var called atomic.Int64
+
+	err := gloop.ParallelizeErr2(seq, func(_ context.Context, _ string, _ int) error {
+		called.Add(1)
+		cancel()
+		close(cancelled)
+		<-exhausted
+
+		return nil
+	}, gloop.WithParallelizeContext(ctx), gloop.WithParallelizeSerializeKeys(true))
+	require.ErrorIs(t, err, context.Canceled)
+	require.EqualValues(t, 1, called.Load())
+}
+
+func TestParallelize2SerializeKeysPool(t *testing.T) {
+	pool := gloop.NewPool(gloop.WithPoolWorkers(2))
+	t.Cleanup(pool.Close)
+
+	var mu sync.Mutex
+
+	gotEvents := make(map[string][]int)
+
+	gloop.Parallelize2(accountEvents(3, 10), func(account string, event int) {
+		mu.Lock()
+		gotEvents[account] = append(gotEvents[account], event)
+		mu.Unlock()
+	}, gloop.WithParallelizeSerializeKeys(true), gloop.WithParallelizePool(pool))
+
+	require.Len(t, gotEvents, 3)
+
+	for _, accountEvents := range gotEvents {
+		require.Equal(t, gloop.ToSlice(gloop.Interval(0, 10, 1)), accountEvents)
+	}
+}
+
+func TestParallelize2SerializeKeysQueuedValuesTakeNoThread(t *testing.T) {
+	testcases := map[string]struct {
+		opts []gloop.ParallelizeOptionFunc
+	}{
+		"Max threads": {
+			opts: []gloop.ParallelizeOptionFunc{
+				gloop.WithParallelizeMaxThreads(2),
+			},
+		},
+		"Max weight": {
+			opts: []gloop.ParallelizeOptionFunc{
+				gloop.WithParallelizeWeight[int](2, nil),
+			},
+		},
+		"Priority": {
+			opts: []gloop.ParallelizeOptionFunc{
+				gloop.WithParallelizeMaxThreads(2),
+				gloop.WithParallelizePriority(func(_ int) int {
+					return 0
+				}, 4),
+			},
+		},
+	}
+
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			seq := func(yield func(string, int) bool) {
+				_ = yield("Fizz", 0) && yield("Fizz", 1) && yield("Buzz", 0)
+			}
+
+			var mu sync.Mutex
+
+			gotEvents := make([]string, 0)
+			buzzCalled := make(chan struct{})
+
+			opts := append(testcase.opts, gloop.WithParallelizeSerializeKeys(true))
+
+			done := make(chan struct{}, 1)
+			go func() {
+				gloop.Parallelize2(seq, func(key string, event int) {
+					if key == "Fizz" && event == 0 {
+						<-buzzCalled
+					}
+
+					mu.Lock()
+					gotEvents = append(gotEvents, fmt.Sprintf("%s-%d", key, event))
+					mu.Unlock()
+
+					if key == "Buzz" {
+						close(buzzCalled)
+					}
+				}, opts...)
+				done <- struct{}{}
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(time.Second * 10):
+				t.Fatal("done signal took too long")
+			}
+
+			require.Equal(t, []string{"Buzz-0", "Fizz-0", "Fizz-1"}, gotEvents)
+		})
+	}
+}
+
+func TestParallelize2SerializeKeysBoundsGoroutines(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	var maxGoroutines atomic.Int64
+
+	gloop.Parallelize2(accountEvents(1, 1000), func(_ string, _ int) {
+		n := int64(runtime.NumGoroutine())
+
+		for {
+			m := maxGoroutines.Load()
+			if n <= m || maxGoroutines.CompareAndSwap(m, n) {
+				break
+			}
+		}
+	}, gloop.WithParallelizeSerializeKeys(true))
+
+	require.Less(t, maxGoroutines.Load(), int64(before+10))
+}
+
+func TestParallelizeErr2SerializeKeysRateLimitCancelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	clock := newFakeClock(time.Now())
+
+	var called atomic.Int64
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr2(accountEvents(1, 3), func(_ context.Context, _ string, _ int) error {
+			called.Add(1)
+
+			return nil
+		},
+			gloop.WithParallelizeContext(ctx),
+			gloop.WithParallelizeRateLimit(1, 1),
+			gloop.WithParallelizeClock(clock),
+			gloop.WithParallelizeSerializeKeys(true),
+		)
+	}()
+
+	clock.WaitForAfter(t)
+	cancel()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 1, called.Load())
+}
+
+func TestParallelize2SerializeKeysNotComparablePanics(t *testing.T) {
+	require.PanicsWithValue(t, "keys must be comparable", func() {
+		gloop.Parallelize2(func(yield func([]int, int) bool) {
+			yield([]int{1}, 1)
+		}, func(_ []int, _ int) {}, gloop.WithParallelizeSerializeKeys(true))
+	})
+
+	require.PanicsWithValue(t, "keys must be comparable", func() {
+		gloop.Parallelize2(func(yield func(any, int) bool) {
+			_ = yield(1, 1) && yield([]int{1}, 2)
+		}, func(_ any, _ int) {}, gloop.WithParallelizeSerializeKeys(true))
+	})
+}
+
+func TestWithParallelizeTaskTimeout(t *testing.T) {
+	options := gloop.ParallelizeOptions{}
+	gloop.WithParallelizeTaskTimeout(time.Second)(&options)
+
+	require.Equal(t, time.Second, options.TaskTimeout)
+}
+
+func TestParallelizeContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	values := []string{"Fizz", "Buzz", "Bazz"}
+
+	var mu sync.Mutex
+
+	gotValues := make([]string, 0)
+
+	done := make(chan struct{}, 1)
+	go func() {
+		gloop.ParallelizeContext(gloop.Slice(values), func(ctx context.Context, v string) {
+			require.NoError(t, ctx.Err())
+
+			mu.Lock()
+			gotValues = append(gotValues, v)
+			mu.Unlock()
+		}, gloop.WithParallelizeContext(ctx))
+		done <- struct{}{}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.ElementsMatch(t, values, gotValues)
+}
+
+func TestParallelizeContext2(t *testing.T) {
+	m := map[string]int{
+		"Fizz": 3,
+		"Buzz": 1,
+		"Bazz": 4,
+	}
+
+	var mu sync.Mutex
+
+	gotMap := make(map[string]int)
+
+	done := make(chan struct{}, 1)
+	go func() {
+		gloop.ParallelizeContext2(gloop.Map(m), func(ctx context.Context, k string, v int) {
+			_, ok := ctx.Deadline()
+			require.False(t, ok)
+
+			mu.Lock()
+			gotMap[k] = v
+			mu.Unlock()
+		})
+		done <- struct{}{}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.Equal(t, m, gotMap)
+}
+
+func TestParallelizeContextCancelContextStopsChannel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	ch := make(chan string)
+	t.Cleanup(func() {
+		close(ch)
+	})
+
+	called := make(chan string, 2)
+
+	done := make(chan struct{}, 1)
+	go func() {
+		gloop.ParallelizeContext(gloop.Channel(ch), func(_ context.Context, v string) {
+			called <- v
+		}, gloop.WithParallelizeContext(ctx))
+		done <- struct{}{}
+	}()
+
+	ch <- "Fizz"
+	ch <- "Buzz"
+
+	gotValues := []string{<-called, <-called}
+	require.ElementsMatch(t, []string{"Fizz", "Buzz"}, gotValues)
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestParallelizeContextCancelContextCancelsFunctions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	pulledSecond := make(chan struct{})
+
+	seq := func(yield func(string) bool) {
+		if !yield("Fizz") {
+			return
+		}
+
+		close(pulledSecond)
+
+		if !yield("Buzz") {
+			return
+		}
+
+		t.Error("expected no further values to be pulled")
+	}
+
+	var called atomic.Int64
+
+	done := make(chan struct{}, 1)
+	go func() {
+		gloop.ParallelizeContext(seq, func(ctx context.Context, _ string) {
+			called.Add(1)
+
+			<-pulledSecond
+			time.Sleep(time.Millisecond * 10)
+			cancel()
+
+			<-ctx.Done()
+		}, gloop.WithParallelizeContext(ctx), gloop.WithParallelizeMaxThreads(1))
+		done <- struct{}{}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 1, called.Load())
+}
+
+func TestParallelizeContextTaskTimeout(t *testing.T) {
+	values := []string{"Fizz", "Buzz"}
+	errs := make(chan error, len(values))
+
+	done := make(chan struct{}, 1)
+	go func() {
+		gloop.ParallelizeContext(gloop.Slice(values), func(ctx context.Context, _ string) {
+			_, ok := ctx.Deadline()
+			require.True(t, ok)
+
+			<-ctx.Done()
+			errs <- ctx.Err()
+		}, gloop.WithParallelizeTaskTimeout(time.Millisecond*10))
+		done <- struct{}{}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	close(errs)
+
+	for err := range errs {
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+}
+
+func TestParallelizeErrTaskTimeoutRetry(t *testing.T) {
+	var attempts atomic.Int64
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Slice([]string{"Fizz"}), func(ctx context.Context, _ string) error {
+			attempts.Add(1)
+
+			<-ctx.Done()
+
+			return ctx.Err()
+		},
+			gloop.WithParallelizeTaskTimeout(time.Millisecond*10),
+			gloop.WithParallelizeRetry(gloop.RetryPolicy{MaxAttempts: 2}),
+		)
+	}()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 2, attempts.Load())
+}
+
+func TestParallelizeErrTaskTimeoutInvalidPanics(t *testing.T) {
+	require.Panics(t, func() {
+		_ = gloop.ParallelizeErr(gloop.Slice([]string{"Fizz"}), func(_ context.Context, _ string) error {
+			return nil
+		}, gloop.WithParallelizeTaskTimeout(-time.Second))
+	})
+}
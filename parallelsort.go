@@ -0,0 +1,185 @@
+package gloop
+
+import (
+	"cmp"
+	"context"
+	"iter"
+	"runtime"
+	"slices"
+)
+
+// ParallelSort allows looping over an [iter.Seq] sequence in sorted
+// order, sorting on separate goroutines. The values are the same as
+// those yielded by [Sort].
+func ParallelSort[V cmp.Ordered](
+	seq iter.Seq[V],
+	ascending bool,
+	opts ...ParallelizeOptionFunc,
+) iter.Seq[V] {
+	return ParallelSortByComparison(seq, func(value1 V, value2 V) bool {
+		return value1 < value2
+	}, ascending, opts...)
+}
+
+// ParallelSortByComparison allows looping over an [iter.Seq] sequence
+// in sorted order using a comparison function, sorting on separate
+// goroutines. The sequence is split into chunks which are sorted
+// concurrently and then merged in pairs, with large merges split
+// between goroutines. The sort is stable, and the values are the same
+// as those yielded by [SortByComparison]. If the chunk size is not
+// configured, the sequence is split evenly between the maximum number
+// of threads. If the maximum number of threads is not configured, it
+// defaults to [runtime.GOMAXPROCS]. If the context is cancelled before
+// sorting completes, no values are yielded.
+func ParallelSortByComparison[V any](
+	seq iter.Seq[V],
+	less SortByComparisonFunc[V],
+	ascending bool,
+	opts ...ParallelizeOptionFunc,
+) iter.Seq[V] {
+	options := newParallelizeOptions(opts...)
+
+	maxThreads := runtime.GOMAXPROCS(0)
+	if options.MaxThreads != nil {
+		maxThreads = *options.MaxThreads
+	}
+
+	if maxThreads <= 0 {
+		panic("max threads must be positive")
+	}
+
+	validateNoValueFuncs(options)
+
+	values := ToSlice(seq)
+
+	chunkSize := options.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = max(1, (len(values)+maxThreads-1)/maxThreads)
+	}
+
+	if chunkSize < 0 {
+		panic("chunk size must not be negative")
+	}
+
+	opts = append(slices.Clone(opts), WithParallelizeMaxThreads(maxThreads))
+	parallelSortStable(values, sortLess(less, ascending), chunkSize, maxThreads, opts...)
+
+	if options.Context.Err() != nil {
+		return func(yield func(V) bool) {}
+	}
+
+	return func(yield func(V) bool) {
+		for _, value := range values {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// ParallelSortByRank allows looping over an [iter.Seq] sequence in
+// sorted order using a ranking function, sorting on separate
+// goroutines. The values are the same as those yielded by
+// [SortByRank].
+func ParallelSortByRank[V any, R cmp.Ordered](
+	seq iter.Seq[V],
+	rank SortByRankFunc[V, R],
+	ascending bool,
+	opts ...ParallelizeOptionFunc,
+) iter.Seq[V] {
+	return ParallelSortByComparison(seq, func(value1 V, value2 V) bool {
+		return rank(value1) < rank(value2)
+	}, ascending, opts...)
+}
+
+// parallelSortStable sorts a slice in place using a given comparison
+// function by sorting chunks of a given size concurrently and merging
+// them in rounds, keeping equal values in their original order.
+func parallelSortStable[V any](
+	values []V,
+	less SortByComparisonFunc[V],
+	chunkSize int,
+	maxThreads int,
+	opts ...ParallelizeOptionFunc,
+) {
+	n := len(values)
+
+	_ = ParallelFor(0, n, func(_ context.Context, low int, high int) error {
+		sortStable(values[low:high], less)
+
+		return nil
+	}, append(slices.Clone(opts), WithParallelizeChunkSize(chunkSize))...)
+
+	src := values
+	dst := make([]V, n)
+
+	for width := chunkSize; width < n; width *= 2 {
+		pairs := (n + 2*width - 1) / (2 * width)
+		parts := max(1, maxThreads/pairs)
+
+		// each pair of adjacent runs is merged in parts of roughly
+		// equal size, so that a few large merges still use every
+		// thread.
+		_ = ParallelFor(0, pairs*parts, func(_ context.Context, segment int, _ int) error {
+			pair, part := segment/parts, segment%parts
+
+			low := pair * 2 * width
+			mid := min(low+width, n)
+			high := min(low+2*width, n)
+			run1, run2 := src[low:mid], src[mid:high]
+
+			k1 := (high - low) * part / parts
+			k2 := (high - low) * (part + 1) / parts
+			i1 := mergeSplit(run1, run2, k1, less)
+			i2 := mergeSplit(run1, run2, k2, less)
+
+			mergeStable(dst[low+k1:low+k2], run1[i1:i2], run2[k1-i1:k2-i2], less)
+
+			return nil
+		}, append(slices.Clone(opts), WithParallelizeChunkSize(1))...)
+
+		src, dst = dst, src
+	}
+
+	if n > 0 && &src[0] != &values[0] {
+		copy(values, src)
+	}
+}
+
+// mergeSplit returns the number of values taken from the first of two
+// sorted runs within the first k values of their stable merge.
+func mergeSplit[V any](run1 []V, run2 []V, k int, less SortByComparisonFunc[V]) int {
+	low, high := max(0, k-len(run2)), min(k, len(run1))
+
+	for low < high {
+		i := (low + high) / 2
+		if less(run2[k-i-1], run1[i]) {
+			high = i
+		} else {
+			low = i + 1
+		}
+	}
+
+	return low
+}
+
+// mergeStable merges two sorted runs into a given slice, taking values
+// from the first run before equal values from the second.
+func mergeStable[V any](dst []V, run1 []V, run2 []V, less SortByComparisonFunc[V]) {
+	i, j, k := 0, 0, 0
+
+	for i < len(run1) && j < len(run2) {
+		if less(run2[j], run1[i]) {
+			dst[k] = run2[j]
+			j++
+		} else {
+			dst[k] = run1[i]
+			i++
+		}
+
+		k++
+	}
+
+	k += copy(dst[k:], run1[i:])
+	copy(dst[k:], run2[j:])
+}
@@ -0,0 +1,197 @@
+package gloop_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+type parallelSortRecord struct {
+	Key   int
+	Index int
+}
+
+func TestParallelSort(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5, 9, 2, 6, 5}
+
+	testcases := map[string]struct {
+		ascending  bool
+		wantValues []int
+	}{
+		"Ascending": {
+			ascending:  true,
+			wantValues: []int{1, 1, 2, 3, 4, 5, 5, 6, 9},
+		},
+		"Descending": {
+			ascending:  false,
+			wantValues: []int{9, 6, 5, 5, 4, 3, 2, 1, 1},
+		},
+	}
+
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			gotValues := gloop.ToSlice(gloop.ParallelSort(
+				gloop.Slice(values),
+				testcase.ascending,
+				gloop.WithParallelizeMaxThreads(3),
+			))
+			require.Equal(t, testcase.wantValues, gotValues)
+		})
+	}
+}
+
+func TestParallelSortValueFuncsPanics(t *testing.T) {
+	values := []string{"Fizz", "Buzz", "Bazz"}
+
+	require.PanicsWithValue(t, "weight function is not supported", func() {
+		gloop.ParallelSort(gloop.Slice(values), true, gloop.WithParallelizeWeight(2, func(s string) int64 {
+			return int64(len(s))
+		}))
+	})
+
+	require.PanicsWithValue(t, "priority function is not supported", func() {
+		gloop.ParallelSort(gloop.Slice(values), true, gloop.WithParallelizePriority(func(s string) int {
+			return len(s)
+		}, 4))
+	})
+}
+
+func TestParallelSortByComparisonMatchesSequential(t *testing.T) {
+	sizes := []int{0, 1, 2, 3, 17, 100, 1000}
+	maxThreads := []int{1, 2, 3, 4, 7, 16}
+	chunkSizes := []int{0, 1, 5, 64}
+
+	for _, size := range sizes {
+		for _, threads := range maxThreads {
+			for _, chunkSize := range chunkSizes {
+				for _, ascending := range []bool{true, false} {
+					name := fmt.Sprintf("size %d, threads %d, chunk size %d, ascending %v", size, threads, chunkSize, ascending)
+					t.Run(name, func(t *testing.T) {
+						t.Parallel()
+
+						r := rand.New(rand.NewSource(int64(size*1000 + threads*10 + chunkSize)))
+						records := make([]parallelSortRecord, size)
+
+						for i := range records {
+							records[i] = parallelSortRecord{
+								Key:   r.Intn(max(1, size/4)),
+								Index: i,
+							}
+						}
+
+						less := func(record1 parallelSortRecord, record2 parallelSortRecord) bool {
+							return record1.Key < record2.Key
+						}
+
+						wantRecords := gloop.ToSlice(gloop.SortByComparison(gloop.Slice(records), less, ascending))
+						gotRecords := gloop.ToSlice(gloop.ParallelSortByComparison(
+							gloop.Slice(records),
+							less,
+							ascending,
+							gloop.WithParallelizeMaxThreads(threads),
+							gloop.WithParallelizeChunkSize(chunkSize),
+						))
+						require.Equal(t, wantRecords, gotRecords)
+
+						for i := 1; i < len(gotRecords); i++ {
+							if gotRecords[i].Key == gotRecords[i-1].Key {
+								require.Less(t, gotRecords[i-1].Index, gotRecords[i].Index)
+							}
+						}
+					})
+				}
+			}
+		}
+	}
+}
+
+func TestParallelSortByComparisonDefaultOptions(t *testing.T) {
+	values := gloop.ToSlice(gloop.RandomUniform(0.0, 1.0, 1000))
+
+	wantValues := gloop.ToSlice(gloop.Sort(gloop.Slice(values), true))
+	gotValues := gloop.ToSlice(gloop.ParallelSort(gloop.Slice(values), true))
+
+	require.Equal(t, wantValues, gotValues)
+}
+
+func TestParallelSortByComparisonBreak(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5, 9, 2, 6, 5}
+	wantValues := []int{1, 1, 2}
+
+	i := 0
+	for value := range gloop.ParallelSort(gloop.Slice(values), true) {
+		if i == len(wantValues) {
+			break
+		}
+
+		require.Equal(t, wantValues[i], value)
+
+		i++
+	}
+
+	require.Equal(t, len(wantValues), i)
+}
+
+func TestParallelSortByComparisonCancelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	values := []int{3, 1, 4, 1, 5, 9, 2, 6, 5}
+
+	for range gloop.ParallelSort(gloop.Slice(values), true, gloop.WithParallelizeContext(ctx)) {
+		t.Fatal("expected no iteration")
+	}
+}
+
+func TestParallelSortByComparisonInvalidOptionsPanics(t *testing.T) {
+	values := []int{3, 1, 4}
+
+	require.Panics(t, func() {
+		gloop.ParallelSort(gloop.Slice(values), true, gloop.WithParallelizeMaxThreads(0))
+	})
+
+	require.Panics(t, func() {
+		gloop.ParallelSort(gloop.Slice(values), true, gloop.WithParallelizeChunkSize(-1))
+	})
+}
+
+func TestParallelSortByRank(t *testing.T) {
+	values := []string{"Fizzzzzz", "Buz", "Bazzz", "Fiz", "Bu"}
+
+	testcases := map[string]struct {
+		ascending  bool
+		wantValues []string
+	}{
+		"Ascending": {
+			ascending:  true,
+			wantValues: []string{"Bu", "Buz", "Fiz", "Bazzz", "Fizzzzzz"},
+		},
+		"Descending": {
+			ascending:  false,
+			wantValues: []string{"Fizzzzzz", "Bazzz", "Buz", "Fiz", "Bu"},
+		},
+	}
+
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			gotValues := gloop.ToSlice(gloop.ParallelSortByRank(
+				gloop.Slice(values),
+				func(s string) int {
+					return len(s)
+				},
+				testcase.ascending,
+				gloop.WithParallelizeMaxThreads(2),
+				gloop.WithParallelizeChunkSize(1),
+			))
+			require.Equal(t, testcase.wantValues, gotValues)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package gloop
+
+import (
+	"context"
+	"iter"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// ParallelTransform runs a given function on each value over an
+// [iter.Seq] sequence on separate goroutines and allows looping over
+// the returned values. By default, values are yielded in the order of
+// the sequence, and at most as many results as the maximum number of
+// threads are buffered while waiting for earlier results. If
+// configured as unordered, values are yielded as they complete. If the
+// maximum number of threads is not configured, it defaults to
+// [runtime.GOMAXPROCS]. The sequence is consumed on a separate
+// goroutine. Once looping ends or the context is cancelled, no further
+// values are pulled from the sequence, and looping ends once the
+// functions in progress return, without waiting for the sequence to
+// yield its next value. If a panic is recovered, looping ends,
+// and the panic is reported once the functions in progress return.
+func ParallelTransform[V, R any](
+	seq iter.Seq[V],
+	f TransformFunc[V, R],
+	opts ...ParallelizeOptionFunc,
+) iter.Seq[R] {
+	options := newParallelizeOptions(opts...)
+
+	maxThreads := runtime.GOMAXPROCS(0)
+	if options.MaxThreads != nil {
+		maxThreads = *options.MaxThreads
+	}
+
+	if maxThreads <= 0 {
+		panic("max threads must be positive")
+	}
+
+	_, _ = parallelizeValueFuncs[V](options)
+
+	return func(yield func(R) bool) {
+		ctx, cancel := context.WithCancel(options.Context)
+
+		// results holds the channels that results are sent on, in the
+		// order they are yielded.
+		results := make(chan chan R, maxThreads)
+		done := make(chan struct{})
+
+		var mu sync.Mutex
+		panicErrs := make([]*PanicError, 0)
+
+		defer func() {
+			cancel()
+			<-done
+			reportParallelizePanics(options, panicErrs)
+		}()
+
+		go func() {
+			defer close(done)
+			defer close(results)
+
+			slots := func(yield func(chan R, V) bool) {
+				for value := range contextSeq(ctx, seq) {
+					slot := make(chan R, 1)
+
+					if !options.Unordered {
+						select {
+						case results <- slot:
+						case <-ctx.Done():
+							return
+						}
+					}
+
+					if !yield(slot, value) {
+						return
+					}
+				}
+			}
+
+			parallelizeOpts := append(
+				slices.Clone(opts),
+				WithParallelizeContext(ctx),
+				WithParallelizeMaxThreads(maxThreads),
+				WithParallelizeRecoverPanics(false),
+				WithParallelizePanicHandler(nil),
+				WithParallelizeRepanic(false),
+			)
+
+			_ = ParallelizeErr2(slots, func(ctx context.Context, slot chan R, value V) error {
+				var result R
+
+				panicErr := recoverParallelizePanic(options, func() {
+					result = f(value)
+				})
+				if panicErr != nil {
+					mu.Lock()
+					panicErrs = append(panicErrs, panicErr)
+					mu.Unlock()
+
+					cancel()
+
+					return nil
+				}
+
+				slot <- result
+
+				if options.Unordered {
+					select {
+					case results <- slot:
+					case <-ctx.Done():
+					}
+				}
+
+				return nil
+			}, parallelizeOpts...)
+		}()
+
+		for ctx.Err() == nil {
+			var slot chan R
+			var ok bool
+
+			select {
+			case slot, ok = <-results:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			var result R
+
+			select {
+			case result = <-slot:
+			case <-ctx.Done():
+				return
+			}
+
+			if !yield(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,422 @@
+package gloop_test
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParallelTransformOrdered(t *testing.T) {
+	values := []int{5, 1, 4, 2, 3}
+	wantValues := []int{10, 2, 8, 4, 6}
+
+	done := make(chan []int, 1)
+	go func() {
+		done <- gloop.ToSlice(gloop.ParallelTransform(gloop.Slice(values), func(v int) int {
+			time.Sleep(time.Duration(v) * time.Millisecond)
+
+			return v * 2
+		}))
+	}()
+
+	select {
+	case gotValues := <-done:
+		require.Equal(t, wantValues, gotValues)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestParallelTransformUnordered(t *testing.T) {
+	values := []int{30, 1, 2}
+	release := make(chan struct{})
+
+	done := make(chan []int, 1)
+	go func() {
+		gotValues := make([]int, 0)
+
+		for value := range gloop.ParallelTransform(gloop.Slice(values), func(v int) int {
+			if v == 30 {
+				<-release
+			}
+
+			return v
+		}, gloop.WithParallelizeUnordered(true), gloop.WithParallelizeMaxThreads(3)) {
+			gotValues = append(gotValues, value)
+
+			if len(gotValues) == 2 {
+				close(release)
+			}
+		}
+
+		done <- gotValues
+	}()
+
+	select {
+	case gotValues := <-done:
+		require.ElementsMatch(t, []int{1, 2}, gotValues[:2])
+		require.Equal(t, 30, gotValues[2])
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestParallelTransformMaxThreads(t *testing.T) {
+	values := make([]int, 20)
+
+	var (
+		concurrentCallers    atomic.Int64
+		maxConcurrentCallers atomic.Int64
+	)
+
+	done := make(chan []int, 1)
+	go func() {
+		done <- gloop.ToSlice(gloop.ParallelTransform(gloop.Slice(values), func(v int) int {
+			n := concurrentCallers.Add(1)
+			defer concurrentCallers.Add(-1)
+
+			for {
+				m := maxConcurrentCallers.Load()
+				if n <= m || maxConcurrentCallers.CompareAndSwap(m, n) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+
+			return v
+		}, gloop.WithParallelizeMaxThreads(3)))
+	}()
+
+	select {
+	case gotValues := <-done:
+		require.Len(t, gotValues, len(values))
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.LessOrEqual(t, maxConcurrentCallers.Load(), int64(3))
+}
+
+func TestParallelTransformBoundedBuffer(t *testing.T) {
+	maxThreads := 2
+	release := make(chan struct{})
+
+	var (
+		pulled    atomic.Int64
+		completed atomic.Int64
+	)
+
+	seq := func(yield func(int) bool) {
+		for i := range 100 {
+			pulled.Add(1)
+
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	done := make(chan []int, 1)
+	go func() {
+		done <- gloop.ToSlice(gloop.ParallelTransform(seq, func(v int) int {
+			if v == 0 {
+				<-release
+			}
+
+			completed.Add(1)
+
+			return v
+		}, gloop.WithParallelizeMaxThreads(maxThreads)))
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	require.LessOrEqual(t, completed.Load(), int64(maxThreads))
+	require.LessOrEqual(t, pulled.Load(), int64(2*maxThreads+1))
+
+	close(release)
+
+	select {
+	case gotValues := <-done:
+		require.Equal(t, gloop.ToSlice(gloop.Interval(0, 100, 1)), gotValues)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestParallelTransformBreak(t *testing.T) {
+	var (
+		pulled  atomic.Int64
+		running atomic.Int64
+	)
+
+	seq := func(yield func(int) bool) {
+		for i := range 100 {
+			pulled.Add(1)
+
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	done := make(chan struct{}, 1)
+	go func() {
+		for value := range gloop.ParallelTransform(seq, func(v int) int {
+			running.Add(1)
+			defer running.Add(-1)
+
+			time.Sleep(time.Millisecond)
+
+			return v
+		}, gloop.WithParallelizeMaxThreads(2)) {
+			if value == 3 {
+				break
+			}
+		}
+
+		done <- struct{}{}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 0, running.Load())
+	require.Less(t, pulled.Load(), int64(100))
+}
+
+func TestParallelTransformBreakUnordered(t *testing.T) {
+	values := gloop.Interval(0, 100, 1)
+
+	var running atomic.Int64
+
+	done := make(chan struct{}, 1)
+	go func() {
+		for range gloop.ParallelTransform(values, func(v int) int {
+			running.Add(1)
+			defer running.Add(-1)
+
+			return v
+		}, gloop.WithParallelizeUnordered(true), gloop.WithParallelizeMaxThreads(4)) {
+			break
+		}
+
+		done <- struct{}{}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 0, running.Load())
+}
+
+func TestParallelTransformBreakChannel(t *testing.T) {
+	ch := make(chan int, 1)
+	t.Cleanup(func() {
+		close(ch)
+	})
+
+	ch <- 3
+
+	done := make(chan int, 1)
+	go func() {
+		got := 0
+
+		for value := range gloop.ParallelTransform(gloop.Channel(ch), func(v int) int {
+			return v * v
+		}) {
+			got = value
+
+			break
+		}
+
+		done <- got
+	}()
+
+	select {
+	case value := <-done:
+		require.Equal(t, 9, value)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestParallelTransformCancelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	var pulled atomic.Int64
+
+	seq := func(yield func(int) bool) {
+		for i := range 100 {
+			pulled.Add(1)
+
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	done := make(chan []int, 1)
+	go func() {
+		gotValues := make([]int, 0)
+
+		for value := range gloop.ParallelTransform(seq, func(v int) int {
+			return v
+		}, gloop.WithParallelizeContext(ctx), gloop.WithParallelizeMaxThreads(1)) {
+			gotValues = append(gotValues, value)
+
+			time.Sleep(50 * time.Millisecond)
+			cancel()
+		}
+
+		done <- gotValues
+	}()
+
+	select {
+	case gotValues := <-done:
+		require.Equal(t, []int{0}, gotValues)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.Less(t, pulled.Load(), int64(100))
+}
+
+func TestParallelTransformCancelContextWhileWaiting(t *testing.T) {
+	testcases := map[string]struct {
+		unordered bool
+	}{
+		"Ordered": {
+			unordered: false,
+		},
+		"Unordered": {
+			unordered: true,
+		},
+	}
+
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+
+			done := make(chan []int, 1)
+			go func() {
+				done <- gloop.ToSlice(gloop.ParallelTransform(gloop.Interval(0, 100, 1), func(v int) int {
+					if v == 0 {
+						<-ctx.Done()
+					}
+
+					if v == 1 {
+						time.Sleep(50 * time.Millisecond)
+						cancel()
+						<-ctx.Done()
+					}
+
+					return v
+				},
+					gloop.WithParallelizeContext(ctx),
+					gloop.WithParallelizeMaxThreads(2),
+					gloop.WithParallelizeUnordered(testcase.unordered),
+				))
+			}()
+
+			select {
+			case gotValues := <-done:
+				require.LessOrEqual(t, len(gotValues), 1)
+			case <-time.After(time.Second * 10):
+				t.Fatal("done signal took too long")
+			}
+		})
+	}
+}
+
+func TestParallelTransformDefaultMaxThreads(t *testing.T) {
+	values := gloop.ToSlice(gloop.Interval(0, 4*runtime.GOMAXPROCS(0), 1))
+
+	gotValues := gloop.ToSlice(gloop.ParallelTransform(gloop.Slice(values), func(v int) int {
+		return v + 1
+	}))
+
+	for i, value := range gotValues {
+		require.Equal(t, values[i]+1, value)
+	}
+}
+
+func TestParallelTransformInvalidMaxThreads(t *testing.T) {
+	require.Panics(t, func() {
+		gloop.ParallelTransform(gloop.Slice([]int{}), func(v int) int {
+			return v
+		}, gloop.WithParallelizeMaxThreads(0))
+	})
+}
+
+func TestParallelTransformRecoverPanics(t *testing.T) {
+	panicValues := make([]any, 0)
+
+	done := make(chan []int, 1)
+	go func() {
+		done <- gloop.ToSlice(gloop.ParallelTransform(gloop.Interval(0, 100, 1), func(v int) int {
+			if v == 2 {
+				panic(v)
+			}
+
+			return v
+		},
+			gloop.WithParallelizeMaxThreads(1),
+			gloop.WithParallelizePanicHandler(func(err *gloop.PanicError) {
+				panicValues = append(panicValues, err.Value)
+			}),
+		))
+	}()
+
+	select {
+	case gotValues := <-done:
+		require.Equal(t, []int{0, 1}, gotValues)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.Equal(t, []any{2}, panicValues)
+}
+
+func TestParallelTransformRepanic(t *testing.T) {
+	done := make(chan any, 1)
+	go func() {
+		defer func() {
+			done <- recover()
+		}()
+
+		for range gloop.ParallelTransform(gloop.Interval(0, 100, 1), func(v int) int {
+			if v == 2 {
+				panic(v)
+			}
+
+			return v
+		}, gloop.WithParallelizeRepanic(true), gloop.WithParallelizeUnordered(true)) {
+		}
+	}()
+
+	select {
+	case value := <-done:
+		panicErr, ok := value.(*gloop.PanicError)
+		require.True(t, ok)
+		require.Equal(t, 2, panicErr.Value)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
@@ -0,0 +1,202 @@
+package gloop
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// PipelineOptions defines configurable options for [NewPipeline].
+type PipelineOptions struct {
+	// Context is used to send a cancel signal to every stage.
+	Context context.Context
+}
+
+// PipelineOptionFunc is the function signature of configuration
+// helpers for [NewPipeline].
+type PipelineOptionFunc func(*PipelineOptions)
+
+// WithPipelineContext is a helper for configuring context in
+// [NewPipeline].
+func WithPipelineContext(ctx context.Context) PipelineOptionFunc {
+	return func(o *PipelineOptions) {
+		o.Context = ctx
+	}
+}
+
+// Pipeline is a sequence of values passed through concurrent stages.
+// Pipelines are created with [NewPipeline], extended with [Stage] and
+// looped over with [Pipeline.Seq].
+type Pipeline[V any] struct {
+	// seq returns the sequence of values coming out of the last stage,
+	// stopping every stage once a given context is cancelled.
+	seq func(context.Context) iter.Seq[V]
+	ctx context.Context
+}
+
+// NewPipeline creates a [Pipeline] with no stages over an [iter.Seq]
+// sequence.
+func NewPipeline[V any](seq iter.Seq[V], opts ...PipelineOptionFunc) Pipeline[V] {
+	options := PipelineOptions{
+		Context: context.Background(),
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	ctx := context.Background()
+	if options.Context != nil {
+		ctx = options.Context
+	}
+
+	return Pipeline[V]{
+		seq: func(ctx context.Context) iter.Seq[V] {
+			return contextSeq(ctx, seq)
+		},
+		ctx: ctx,
+	}
+}
+
+// Seq allows looping over the values coming out of the last stage of
+// the [Pipeline]. Looping ends once the context is cancelled, even if
+// the sequence the [Pipeline] was created with is blocked. Once looping
+// ends, every stage stops and Seq waits for the functions in progress
+// to return.
+func (p Pipeline[V]) Seq() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for value := range p.seq(p.ctx) {
+			if p.ctx.Err() != nil || !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// StageOptions defines configurable options for [Stage].
+type StageOptions struct {
+	// Workers defines the number of goroutines running the function.
+	// It must be positive.
+	Workers int
+	// Buffer defines the number of values buffered between the stage
+	// and the next one. It must not be negative.
+	Buffer int
+}
+
+// StageOptionFunc is the function signature of configuration helpers
+// for [Stage].
+type StageOptionFunc func(*StageOptions)
+
+// WithStageWorkers is a helper for configuring the number of
+// goroutines running the function in [Stage].
+func WithStageWorkers(workers int) StageOptionFunc {
+	return func(o *StageOptions) {
+		o.Workers = workers
+	}
+}
+
+// WithStageBuffer is a helper for configuring the number of values
+// buffered after [Stage].
+func WithStageBuffer(buffer int) StageOptionFunc {
+	return func(o *StageOptions) {
+		o.Buffer = buffer
+	}
+}
+
+// Stage extends a [Pipeline] with a stage that runs a given function on
+// each value on a given number of goroutines, sending the returned
+// values to the next stage through a buffer of a given size. Once the
+// buffer is full, the stage waits for the next stage to catch up.
+// Values are passed on in order only if there is a single goroutine.
+// By default, the stage has a single goroutine and no buffer.
+func Stage[V, R any](p Pipeline[V], f TransformFunc[V, R], opts ...StageOptionFunc) Pipeline[R] {
+	options := StageOptions{
+		Workers: 1,
+		Buffer:  0,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.Workers <= 0 {
+		panic("workers must be positive")
+	}
+
+	if options.Buffer < 0 {
+		panic("buffer must not be negative")
+	}
+
+	seq := func(parent context.Context) iter.Seq[R] {
+		return func(yield func(R) bool) {
+			ctx, cancel := context.WithCancel(parent)
+			in := make(chan V)
+			out := make(chan R, options.Buffer)
+
+			var wg sync.WaitGroup
+
+			defer func() {
+				cancel()
+				wg.Wait()
+			}()
+
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+				defer close(in)
+
+				for value := range p.seq(ctx) {
+					select {
+					case in <- value:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+
+			var workers sync.WaitGroup
+
+			for range options.Workers {
+				workers.Add(1)
+
+				go func() {
+					defer workers.Done()
+
+					for value := range in {
+						select {
+						case out <- f(value):
+						case <-ctx.Done():
+							return
+						}
+					}
+				}()
+			}
+
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				workers.Wait()
+				close(out)
+			}()
+
+			for ctx.Err() == nil {
+				select {
+				case value, ok := <-out:
+					if !ok || !yield(value) {
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}
+
+	return Pipeline[R]{
+		seq: seq,
+		ctx: p.ctx,
+	}
+}
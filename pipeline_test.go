@@ -0,0 +1,359 @@
+package gloop_test
+
+import (
+	"context"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+func requireNoGoroutineLeak(t *testing.T, before int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second * 10)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutines leaked: %d before, %d after", before, runtime.NumGoroutine())
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWithPipelineContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	options := gloop.PipelineOptions{}
+	gloop.WithPipelineContext(ctx)(&options)
+
+	require.Equal(t, ctx, options.Context)
+}
+
+func TestWithStageWorkers(t *testing.T) {
+	options := gloop.StageOptions{}
+	gloop.WithStageWorkers(4)(&options)
+
+	require.Equal(t, 4, options.Workers)
+}
+
+func TestWithStageBuffer(t *testing.T) {
+	options := gloop.StageOptions{}
+	gloop.WithStageBuffer(8)(&options)
+
+	require.Equal(t, 8, options.Buffer)
+}
+
+func TestPipelineNoStages(t *testing.T) {
+	values := []int{3, 1, 4}
+
+	require.Equal(t, values, gloop.ToSlice(gloop.NewPipeline(gloop.Slice(values)).Seq()))
+}
+
+func TestPipelineNoStagesBreak(t *testing.T) {
+	i := 0
+
+	for range gloop.NewPipeline(gloop.Slice([]int{3, 1, 4})).Seq() {
+		i++
+
+		break
+	}
+
+	require.Equal(t, 1, i)
+}
+
+func TestPipelineStages(t *testing.T) {
+	values := gloop.ToSlice(gloop.Interval(0, 100, 1))
+	wantValues := make([]string, len(values))
+
+	for i, value := range values {
+		wantValues[i] = strconv.Itoa(value * value)
+	}
+
+	squared := gloop.Stage(gloop.NewPipeline(gloop.Slice(values)), func(value int) int {
+		return value * value
+	}, gloop.WithStageWorkers(4), gloop.WithStageBuffer(2))
+	formatted := gloop.Stage(squared, strconv.Itoa, gloop.WithStageWorkers(2), gloop.WithStageBuffer(5))
+
+	require.ElementsMatch(t, wantValues, gloop.ToSlice(formatted.Seq()))
+}
+
+func TestPipelineSingleWorkerOrdered(t *testing.T) {
+	values := gloop.ToSlice(gloop.Interval(0, 100, 1))
+
+	doubled := gloop.Stage(gloop.NewPipeline(gloop.Slice(values)), func(value int) int {
+		return value * 2
+	}, gloop.WithStageBuffer(3))
+	incremented := gloop.Stage(doubled, func(value int) int {
+		return value + 1
+	})
+
+	i := 0
+
+	for value := range incremented.Seq() {
+		require.Equal(t, values[i]*2+1, value)
+
+		i++
+	}
+
+	require.Equal(t, len(values), i)
+}
+
+func TestPipelineStageWorkers(t *testing.T) {
+	workers := 3
+
+	var (
+		concurrentCallers    atomic.Int64
+		maxConcurrentCallers atomic.Int64
+	)
+
+	stage := gloop.Stage(gloop.NewPipeline(gloop.Interval(0, 30, 1)), func(value int) int {
+		n := concurrentCallers.Add(1)
+		defer concurrentCallers.Add(-1)
+
+		for {
+			m := maxConcurrentCallers.Load()
+			if n <= m || maxConcurrentCallers.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond * 5)
+
+		return value
+	}, gloop.WithStageWorkers(workers))
+
+	require.Len(t, gloop.ToSlice(stage.Seq()), 30)
+	require.EqualValues(t, workers, maxConcurrentCallers.Load())
+}
+
+func TestPipelineBackpressure(t *testing.T) {
+	var pulled atomic.Int64
+
+	seq := func(yield func(int) bool) {
+		for i := range 100 {
+			pulled.Add(1)
+
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	first := gloop.Stage(gloop.NewPipeline(seq), func(value int) int {
+		return value
+	}, gloop.WithStageBuffer(2))
+	second := gloop.Stage(first, func(value int) int {
+		return value
+	}, gloop.WithStageBuffer(3))
+
+	for range second.Seq() {
+		time.Sleep(time.Millisecond * 50)
+
+		// The second stage holds 3 buffered values and 1 in progress,
+		// the first stage holds 2 buffered values and 1 in progress,
+		// and each stage holds 1 value waiting to be processed.
+		require.LessOrEqual(t, pulled.Load(), int64(11))
+
+		break
+	}
+}
+
+func TestPipelineBreak(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	var running atomic.Int64
+
+	f := func(value int) int {
+		running.Add(1)
+		defer running.Add(-1)
+
+		time.Sleep(time.Millisecond)
+
+		return value
+	}
+
+	first := gloop.Stage(gloop.NewPipeline(gloop.Interval(0, 1000, 1)), f, gloop.WithStageWorkers(4))
+	second := gloop.Stage(first, f, gloop.WithStageWorkers(3), gloop.WithStageBuffer(2))
+	third := gloop.Stage(second, f, gloop.WithStageWorkers(2))
+
+	i := 0
+
+	for range third.Seq() {
+		i++
+
+		if i == 5 {
+			break
+		}
+	}
+
+	require.Equal(t, 5, i)
+	require.EqualValues(t, 0, running.Load())
+	requireNoGoroutineLeak(t, before)
+}
+
+func TestPipelineBreakChannel(t *testing.T) {
+	ch := make(chan int, 1)
+	t.Cleanup(func() {
+		close(ch)
+	})
+
+	ch <- 3
+
+	stage := gloop.Stage(gloop.NewPipeline(gloop.Channel(ch)), func(value int) int {
+		return value
+	})
+
+	done := make(chan int, 1)
+	go func() {
+		got := 0
+
+		for value := range stage.Seq() {
+			got = value
+
+			break
+		}
+
+		done <- got
+	}()
+
+	select {
+	case value := <-done:
+		require.Equal(t, 3, value)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestPipelineCancelContext(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	p := gloop.NewPipeline(gloop.Interval(0, 1000, 1), gloop.WithPipelineContext(ctx))
+	first := gloop.Stage(p, func(value int) int {
+		return value
+	}, gloop.WithStageWorkers(4), gloop.WithStageBuffer(4))
+	second := gloop.Stage(first, func(value int) int {
+		if value == 10 {
+			cancel()
+		}
+
+		return value
+	})
+
+	i := 0
+
+	for range second.Seq() {
+		i++
+	}
+
+	require.Less(t, i, 1000)
+	requireNoGoroutineLeak(t, before)
+}
+
+func TestPipelineCancelContextChannel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	ch := make(chan int)
+	t.Cleanup(func() {
+		close(ch)
+	})
+
+	testcases := map[string]struct {
+		pipeline gloop.Pipeline[int]
+	}{
+		"No stages": {
+			pipeline: gloop.NewPipeline(gloop.Channel(ch), gloop.WithPipelineContext(ctx)),
+		},
+		"Stages": {
+			pipeline: gloop.Stage(
+				gloop.NewPipeline(gloop.Channel(ch), gloop.WithPipelineContext(ctx)),
+				func(value int) int {
+					return value
+				},
+				gloop.WithStageWorkers(2),
+			),
+		},
+	}
+
+	time.AfterFunc(time.Millisecond*10, cancel)
+
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			done := make(chan struct{}, 1)
+			go func() {
+				for range testcase.pipeline.Seq() {
+					t.Error("expected no iteration")
+				}
+				done <- struct{}{}
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(time.Second * 10):
+				t.Fatal("done signal took too long")
+			}
+		})
+	}
+}
+
+func TestPipelineCancelContextNoStages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for range gloop.NewPipeline(gloop.Interval(0, 10, 1), gloop.WithPipelineContext(ctx)).Seq() {
+		t.Fatal("expected no iteration")
+	}
+}
+
+func TestPipelineCancelContextWhileWaiting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	stage := gloop.Stage(
+		gloop.NewPipeline(gloop.Interval(0, 10, 1), gloop.WithPipelineContext(ctx)),
+		func(value int) int {
+			cancel()
+			<-ctx.Done()
+
+			return value
+		},
+	)
+
+	for range stage.Seq() {
+		t.Fatal("expected no iteration")
+	}
+}
+
+func TestPipelineNilContext(t *testing.T) {
+	var ctx context.Context
+
+	stage := gloop.Stage(gloop.NewPipeline(gloop.Interval(0, 3, 1), gloop.WithPipelineContext(ctx)), func(value int) int {
+		return value
+	})
+
+	require.Equal(t, []int{0, 1, 2}, gloop.ToSlice(stage.Seq()))
+}
+
+func TestStageInvalidOptionsPanics(t *testing.T) {
+	p := gloop.NewPipeline(gloop.Interval(0, 3, 1))
+	identity := func(value int) int {
+		return value
+	}
+
+	require.Panics(t, func() {
+		gloop.Stage(p, identity, gloop.WithStageWorkers(0))
+	})
+
+	require.Panics(t, func() {
+		gloop.Stage(p, identity, gloop.WithStageBuffer(-1))
+	})
+}
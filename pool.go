@@ -0,0 +1,154 @@
+package gloop
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// PoolOptions defines configurable options for [NewPool].
+type PoolOptions struct {
+	// Workers defines the number of goroutines running tasks. It must
+	// be positive.
+	Workers int
+	// QueueSize defines the number of tasks queued while all workers
+	// are busy. It must not be negative.
+	QueueSize int
+}
+
+// PoolOptionFunc is the function signature of configuration helpers
+// for [NewPool].
+type PoolOptionFunc func(*PoolOptions)
+
+// WithPoolWorkers is a helper for configuring the number of goroutines
+// running tasks in [NewPool].
+func WithPoolWorkers(workers int) PoolOptionFunc {
+	return func(o *PoolOptions) {
+		o.Workers = workers
+	}
+}
+
+// WithPoolQueueSize is a helper for configuring the number of tasks
+// queued while all workers are busy in [NewPool].
+func WithPoolQueueSize(queueSize int) PoolOptionFunc {
+	return func(o *PoolOptions) {
+		o.QueueSize = queueSize
+	}
+}
+
+// PoolStats is a snapshot of the state of a [Pool].
+type PoolStats struct {
+	// Workers is the number of goroutines running tasks.
+	Workers int
+	// Queued is the number of tasks waiting for a worker.
+	Queued int
+	// Busy is the number of workers running a task.
+	Busy int
+	// Completed is the number of tasks that have finished running.
+	Completed int
+}
+
+// Pool is a fixed number of long-lived goroutines that run tasks
+// submitted by [Parallelize], [Parallelize2], [ParallelizeErr],
+// [ParallelizeErr2] and [ParallelTransform] when configured with
+// [WithParallelizePool]. A pool can be shared by any number of
+// concurrent calls, but functions running on it must not submit tasks
+// to the same pool, as that may wait forever for a free worker.
+type Pool struct {
+	workers   int
+	tasks     chan func()
+	wg        sync.WaitGroup
+	mu        sync.RWMutex
+	closed    bool
+	busy      atomic.Int64
+	completed atomic.Int64
+}
+
+// NewPool creates a [Pool] and starts its workers. By default, there
+// are as many workers as [runtime.GOMAXPROCS] and no queue, so tasks
+// are only submitted once a worker is free. The pool must be closed
+// with [Pool.Close] once it is no longer needed.
+func NewPool(opts ...PoolOptionFunc) *Pool {
+	options := PoolOptions{
+		Workers:   runtime.GOMAXPROCS(0),
+		QueueSize: 0,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.Workers <= 0 {
+		panic("workers must be positive")
+	}
+
+	if options.QueueSize < 0 {
+		panic("queue size must not be negative")
+	}
+
+	p := &Pool{
+		workers: options.Workers,
+		tasks:   make(chan func(), options.QueueSize),
+	}
+
+	for range options.Workers {
+		p.wg.Add(1)
+
+		go func() {
+			defer p.wg.Done()
+
+			for task := range p.tasks {
+				p.busy.Add(1)
+				task()
+				p.busy.Add(-1)
+				p.completed.Add(1)
+			}
+		}()
+	}
+
+	return p
+}
+
+// submit waits for a worker or a place in the queue and submits a
+// given task. It returns false if the context is cancelled first, and
+// panics if the pool is closed.
+func (p *Pool) submit(ctx context.Context, task func()) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		panic("pool is closed")
+	}
+
+	select {
+	case p.tasks <- task:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Stats returns a snapshot of the state of the [Pool].
+func (p *Pool) Stats() PoolStats {
+	return PoolStats{
+		Workers:   p.workers,
+		Queued:    len(p.tasks),
+		Busy:      int(p.busy.Load()),
+		Completed: int(p.completed.Load()),
+	}
+}
+
+// Close stops the [Pool] from accepting tasks and waits for queued and
+// running tasks to finish. Submitting tasks to a closed pool panics.
+// Closing a pool more than once has no effect.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.tasks)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
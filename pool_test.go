@@ -0,0 +1,326 @@
+package gloop_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+func requirePoolStats(t *testing.T, pool *gloop.Pool, wantStats gloop.PoolStats) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second * 10)
+	for pool.Stats() != wantStats {
+		if time.Now().After(deadline) {
+			t.Fatalf("pool stats %+v, want %+v", pool.Stats(), wantStats)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWithPoolWorkers(t *testing.T) {
+	options := gloop.PoolOptions{}
+	gloop.WithPoolWorkers(4)(&options)
+
+	require.Equal(t, 4, options.Workers)
+}
+
+func TestWithPoolQueueSize(t *testing.T) {
+	options := gloop.PoolOptions{}
+	gloop.WithPoolQueueSize(8)(&options)
+
+	require.Equal(t, 8, options.QueueSize)
+}
+
+func TestWithParallelizePool(t *testing.T) {
+	pool := gloop.NewPool()
+	t.Cleanup(pool.Close)
+
+	options := gloop.ParallelizeOptions{}
+	gloop.WithParallelizePool(pool)(&options)
+
+	require.Same(t, pool, options.Pool)
+}
+
+func TestNewPoolDefault(t *testing.T) {
+	pool := gloop.NewPool()
+	pool.Close()
+
+	require.Equal(t, gloop.PoolStats{
+		Workers:   runtime.GOMAXPROCS(0),
+		Queued:    0,
+		Busy:      0,
+		Completed: 0,
+	}, pool.Stats())
+}
+
+func TestNewPoolInvalidOptionsPanics(t *testing.T) {
+	require.Panics(t, func() {
+		gloop.NewPool(gloop.WithPoolWorkers(0))
+	})
+
+	require.Panics(t, func() {
+		gloop.NewPool(gloop.WithPoolQueueSize(-1))
+	})
+}
+
+func TestParallelizePool(t *testing.T) {
+	workers := 3
+	pool := gloop.NewPool(gloop.WithPoolWorkers(workers))
+	t.Cleanup(pool.Close)
+
+	var (
+		sum                  atomic.Int64
+		concurrentCallers    atomic.Int64
+		maxConcurrentCallers atomic.Int64
+	)
+
+	done := make(chan struct{}, 1)
+
+	go func() {
+		gloop.Parallelize(gloop.Interval(1, 31, 1), func(value int) {
+			n := concurrentCallers.Add(1)
+			defer concurrentCallers.Add(-1)
+
+			for {
+				m := maxConcurrentCallers.Load()
+				if n <= m || maxConcurrentCallers.CompareAndSwap(m, n) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond * 5)
+			sum.Add(int64(value))
+		}, gloop.WithParallelizePool(pool))
+		done <- struct{}{}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 465, sum.Load())
+	require.EqualValues(t, workers, maxConcurrentCallers.Load())
+	requirePoolStats(t, pool, gloop.PoolStats{
+		Workers:   workers,
+		Queued:    0,
+		Busy:      0,
+		Completed: 30,
+	})
+}
+
+func TestParallelizePoolSharedAcrossCalls(t *testing.T) {
+	pool := gloop.NewPool(gloop.WithPoolWorkers(2), gloop.WithPoolQueueSize(4))
+	t.Cleanup(pool.Close)
+
+	var sum atomic.Int64
+
+	done := make(chan struct{}, 4)
+
+	for range 4 {
+		go func() {
+			gloop.Parallelize2(gloop.Enumerate(gloop.Interval(0, 25, 1)), func(i int, value int) {
+				sum.Add(int64(value))
+			}, gloop.WithParallelizePool(pool))
+			done <- struct{}{}
+		}()
+	}
+
+	for range 4 {
+		select {
+		case <-done:
+		case <-time.After(time.Second * 10):
+			t.Fatal("done signal took too long")
+		}
+	}
+
+	require.EqualValues(t, 4*300, sum.Load())
+	requirePoolStats(t, pool, gloop.PoolStats{
+		Workers:   2,
+		Queued:    0,
+		Busy:      0,
+		Completed: 100,
+	})
+}
+
+func TestParallelizeErrPoolMaxThreads(t *testing.T) {
+	pool := gloop.NewPool(gloop.WithPoolWorkers(4))
+	t.Cleanup(pool.Close)
+
+	var (
+		concurrentCallers    atomic.Int64
+		maxConcurrentCallers atomic.Int64
+	)
+
+	err := gloop.ParallelizeErr(gloop.Interval(0, 20, 1), func(_ context.Context, _ int) error {
+		n := concurrentCallers.Add(1)
+		defer concurrentCallers.Add(-1)
+
+		for {
+			m := maxConcurrentCallers.Load()
+			if n <= m || maxConcurrentCallers.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond * 5)
+
+		return nil
+	}, gloop.WithParallelizePool(pool), gloop.WithParallelizeMaxThreads(2))
+	require.NoError(t, err)
+	require.EqualValues(t, 2, maxConcurrentCallers.Load())
+}
+
+func TestParallelizeErrPoolError(t *testing.T) {
+	pool := gloop.NewPool(gloop.WithPoolWorkers(2))
+	t.Cleanup(pool.Close)
+
+	errFizz := errors.New("fizz error")
+
+	err := gloop.ParallelizeErr(gloop.Interval(0, 100, 1), func(_ context.Context, value int) error {
+		if value == 3 {
+			return errFizz
+		}
+
+		return nil
+	}, gloop.WithParallelizePool(pool))
+	require.ErrorIs(t, err, errFizz)
+}
+
+func TestPoolStats(t *testing.T) {
+	pool := gloop.NewPool(gloop.WithPoolWorkers(1), gloop.WithPoolQueueSize(2))
+	t.Cleanup(pool.Close)
+
+	release := make(chan struct{})
+	done := make(chan struct{}, 1)
+
+	go func() {
+		gloop.Parallelize(gloop.Interval(0, 4, 1), func(_ int) {
+			<-release
+		}, gloop.WithParallelizePool(pool))
+		done <- struct{}{}
+	}()
+
+	requirePoolStats(t, pool, gloop.PoolStats{
+		Workers:   1,
+		Queued:    2,
+		Busy:      1,
+		Completed: 0,
+	})
+
+	close(release)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	requirePoolStats(t, pool, gloop.PoolStats{
+		Workers:   1,
+		Queued:    0,
+		Busy:      0,
+		Completed: 4,
+	})
+}
+
+func TestPoolCloseDrains(t *testing.T) {
+	pool := gloop.NewPool(gloop.WithPoolWorkers(1), gloop.WithPoolQueueSize(3))
+
+	release := make(chan struct{})
+
+	var completed atomic.Int64
+
+	go func() {
+		gloop.Parallelize(gloop.Interval(0, 4, 1), func(_ int) {
+			<-release
+			completed.Add(1)
+		}, gloop.WithParallelizePool(pool))
+	}()
+
+	requirePoolStats(t, pool, gloop.PoolStats{
+		Workers:   1,
+		Queued:    3,
+		Busy:      1,
+		Completed: 0,
+	})
+
+	closed := make(chan struct{}, 1)
+
+	go func() {
+		pool.Close()
+		closed <- struct{}{}
+	}()
+
+	close(release)
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second * 10):
+		t.Fatal("closed signal took too long")
+	}
+
+	require.EqualValues(t, 4, completed.Load())
+	require.Equal(t, 4, pool.Stats().Completed)
+
+	require.NotPanics(t, pool.Close)
+}
+
+func TestParallelizeClosedPoolPanics(t *testing.T) {
+	pool := gloop.NewPool()
+	pool.Close()
+
+	require.PanicsWithValue(t, "pool is closed", func() {
+		gloop.Parallelize(gloop.Interval(0, 3, 1), func(_ int) {}, gloop.WithParallelizePool(pool))
+	})
+}
+
+func TestParallelizeErrPoolCancelContext(t *testing.T) {
+	pool := gloop.NewPool(gloop.WithPoolWorkers(1))
+	t.Cleanup(pool.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	started := make(chan struct{}, 1)
+
+	var calls atomic.Int64
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Interval(0, 10, 1), func(ctx context.Context, _ int) error {
+			calls.Add(1)
+			started <- struct{}{}
+			<-ctx.Done()
+
+			return nil
+		}, gloop.WithParallelizeContext(ctx), gloop.WithParallelizePool(pool))
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(time.Second * 10):
+		t.Fatal("started signal took too long")
+	}
+
+	cancel()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 1, calls.Load())
+}
@@ -0,0 +1,138 @@
+package gloop
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"sync"
+)
+
+// prioritizedPair is a key value pair buffered in prioritySeq2, along
+// with its priority.
+type prioritizedPair[K, V any] struct {
+	pair     KeyValuePair[K, V]
+	priority int
+}
+
+// prioritySeq2 allows looping over an [iter.Seq2] sequence, pulling up
+// to a given number of values ahead of time on a separate goroutine and
+// yielding the buffered value with the highest priority first. Values
+// with equal priorities are yielded in the order of the sequence.
+// Before picking each value, it waits for a given ready function, and
+// if no value is picked after that, it calls a given release function.
+// Once looping ends or the context is cancelled, no further values are
+// pulled from the sequence, and looping ends once the goroutine
+// returns.
+func prioritySeq2[K, V any](
+	ctx context.Context,
+	seq iter.Seq2[K, V],
+	priority func(V) int,
+	size int,
+	ready func() bool,
+	release func(),
+) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var mu sync.Mutex
+		// buffer holds the values pulled but not yet yielded in the
+		// order of the sequence, so that the first value with the
+		// highest priority is also the earliest. Its length bounds the
+		// number of values pulled ahead of time.
+		var buffer []prioritizedPair[K, V]
+		exhausted := false
+
+		// pulled and picked signal that a value was added to or removed
+		// from the buffer, without blocking the side that changed it.
+		pulled := make(chan struct{}, 1)
+		picked := make(chan struct{}, 1)
+		done := make(chan struct{})
+		stopped := make(chan struct{})
+
+		signal := func(c chan struct{}) {
+			select {
+			case c <- struct{}{}:
+			default:
+			}
+		}
+
+		go func() {
+			defer close(stopped)
+			defer func() {
+				mu.Lock()
+				exhausted = true
+				mu.Unlock()
+				signal(pulled)
+			}()
+
+			for key, value := range seq {
+				p := prioritizedPair[K, V]{
+					pair: KeyValuePair[K, V]{
+						Key:   key,
+						Value: value,
+					},
+					priority: priority(value),
+				}
+
+				mu.Lock()
+				buffer = append(buffer, p)
+				full := len(buffer) >= size
+				mu.Unlock()
+				signal(pulled)
+
+				for full {
+					select {
+					case <-picked:
+					case <-done:
+						return
+					}
+
+					mu.Lock()
+					full = len(buffer) >= size
+					mu.Unlock()
+				}
+			}
+		}()
+
+		defer func() {
+			close(done)
+			<-stopped
+		}()
+
+		for ready() {
+			mu.Lock()
+			for len(buffer) == 0 {
+				if exhausted {
+					mu.Unlock()
+					release()
+
+					return
+				}
+
+				mu.Unlock()
+				select {
+				case <-pulled:
+				case <-ctx.Done():
+					release()
+
+					return
+				}
+				mu.Lock()
+			}
+
+			best := 0
+			for i, p := range buffer {
+				if p.priority > buffer[best].priority {
+					best = i
+				}
+			}
+
+			pair := buffer[best].pair
+			buffer = slices.Delete(buffer, best, best+1)
+			mu.Unlock()
+			signal(picked)
+
+			if !yield(pair.Key, pair.Value) {
+				return
+			}
+		}
+	}
+}
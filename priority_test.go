@@ -0,0 +1,292 @@
+package gloop_test
+
+import (
+	"context"
+	"iter"
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+// gatedSeq allows looping over a slice, waiting for a given channel to
+// be closed after yielding the first value and closing another channel
+// once every value is yielded.
+func gatedSeq[V any](values []V, gate <-chan struct{}, exhausted chan<- struct{}) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		defer close(exhausted)
+
+		for i, value := range values {
+			if !yield(value) {
+				return
+			}
+
+			if i == 0 {
+				<-gate
+			}
+		}
+	}
+}
+
+func TestWithParallelizePriority(t *testing.T) {
+	options := gloop.ParallelizeOptions{}
+	gloop.WithParallelizePriority(func(s string) int {
+		return len(s)
+	}, 8)(&options)
+
+	require.NotNil(t, options.Priority)
+	require.Equal(t, 4, options.Priority.(func(string) int)("Fizz"))
+	require.Equal(t, 8, options.PriorityBuffer)
+
+	gloop.WithParallelizePriority[string](nil, 4)(&options)
+
+	require.Nil(t, options.Priority)
+	require.Equal(t, 4, options.PriorityBuffer)
+}
+
+func TestParallelizePriority(t *testing.T) {
+	values := []string{"Fizz", "Bu", "Bazzzz", "Foo", "Ba", "Barrrr"}
+	wantValues := []string{"Fizz", "Bazzzz", "Barrrr", "Foo", "Bu", "Ba"}
+
+	gate := make(chan struct{})
+	exhausted := make(chan struct{})
+	gotValues := make([]string, 0, len(values))
+
+	done := make(chan struct{}, 1)
+	go func() {
+		gloop.Parallelize(gatedSeq(values, gate, exhausted), func(v string) {
+			gotValues = append(gotValues, v)
+
+			if len(gotValues) == 1 {
+				close(gate)
+				<-exhausted
+			}
+		},
+			gloop.WithParallelizeMaxThreads(1),
+			gloop.WithParallelizePriority(func(v string) int {
+				return len(v)
+			}, len(values)),
+		)
+		done <- struct{}{}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.Equal(t, wantValues, gotValues)
+}
+
+func TestParallelizePriorityBuffer(t *testing.T) {
+	var pulled atomic.Int64
+
+	seq := func(yield func(int) bool) {
+		for i := range 10 {
+			pulled.Add(1)
+
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	release := make(chan struct{})
+
+	done := make(chan struct{}, 1)
+	go func() {
+		gloop.Parallelize(seq, func(_ int) {
+			<-release
+		},
+			gloop.WithParallelizeMaxThreads(1),
+			gloop.WithParallelizePriority(func(v int) int {
+				return v
+			}, 2),
+		)
+		done <- struct{}{}
+	}()
+
+	require.Eventually(t, func() bool {
+		return pulled.Load() == 3
+	}, time.Second*10, time.Millisecond)
+
+	time.Sleep(time.Millisecond * 20)
+	require.EqualValues(t, 3, pulled.Load())
+
+	close(release)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 10, pulled.Load())
+}
+
+func TestParallelizePriorityBufferLargerThanSequence(t *testing.T) {
+	var mu sync.Mutex
+	got := make([]int, 0)
+
+	gloop.Parallelize(gloop.Interval(0, 5, 1), func(i int) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		got = append(got, i)
+	},
+		gloop.WithParallelizePriority(func(v int) int {
+			return v
+		}, math.MaxInt),
+	)
+
+	require.ElementsMatch(t, []int{0, 1, 2, 3, 4}, got)
+}
+
+func TestParallelizeErrPriorityBufferCancelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	var pulled atomic.Int64
+
+	seq := func(yield func(int) bool) {
+		for i := range 10 {
+			pulled.Add(1)
+
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	release := make(chan struct{})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(seq, func(_ context.Context, _ int) error {
+			<-release
+
+			return nil
+		},
+			gloop.WithParallelizeContext(ctx),
+			gloop.WithParallelizeMaxThreads(1),
+			gloop.WithParallelizePriority(func(v int) int {
+				return v
+			}, 2),
+		)
+	}()
+
+	require.Eventually(t, func() bool {
+		return pulled.Load() == 3
+	}, time.Second*10, time.Millisecond)
+
+	cancel()
+	close(release)
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 3, pulled.Load())
+}
+
+func TestParallelizeErrPriorityCancelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	ch := make(chan int)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Channel(ch), func(_ context.Context, _ int) error {
+			t.Error("expected no function calls")
+
+			return nil
+		},
+			gloop.WithParallelizeContext(ctx),
+			gloop.WithParallelizePriority(func(v int) int {
+				return v
+			}, 4),
+		)
+	}()
+
+	time.Sleep(time.Millisecond * 10)
+	cancel()
+
+	select {
+	case <-done:
+		t.Fatal("expected to wait for the sequence to return")
+	case <-time.After(time.Millisecond * 10):
+	}
+
+	close(ch)
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestParallelizeErrPriorityWeightCancelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	values := []int64{2, 2, 2}
+
+	var called atomic.Int64
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Slice(values), func(_ context.Context, _ int64) error {
+			called.Add(1)
+			cancel()
+			time.Sleep(time.Millisecond * 10)
+
+			return nil
+		},
+			gloop.WithParallelizeContext(ctx),
+			gloop.WithParallelizeWeight(3, func(v int64) int64 {
+				return v
+			}),
+			gloop.WithParallelizePriority(func(_ int64) int {
+				return 0
+			}, 2),
+		)
+	}()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 1, called.Load())
+}
+
+func TestParallelizePriorityTypeMismatchPanics(t *testing.T) {
+	seq := gloop.Map(map[string]int{"Fizz": 4})
+
+	require.PanicsWithValue(t, "priority function must take the sequence's value type", func() {
+		gloop.Parallelize2(seq, func(_ string, _ int) {}, gloop.WithParallelizePriority(func(key string) int {
+			return len(key)
+		}, 4))
+	})
+}
+
+func TestParallelizePriorityInvalidBufferPanics(t *testing.T) {
+	require.Panics(t, func() {
+		gloop.Parallelize(gloop.Slice([]int{1}), func(_ int) {}, gloop.WithParallelizePriority(func(v int) int {
+			return v
+		}, 0))
+	})
+}
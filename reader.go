@@ -0,0 +1,154 @@
+package gloop
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"iter"
+)
+
+// ReaderOptions defines configurable options for [Scan], [Lines] and
+// [Runes].
+type ReaderOptions struct {
+	// BufferSize is the initial size of the buffer used for reading.
+	BufferSize int
+	// MaxTokenSize is the maximum size of a token in [Scan] and
+	// [Lines]. It must not be smaller than BufferSize. This is not used
+	// in [Runes].
+	MaxTokenSize int
+}
+
+// ReaderOptionFunc is the function signature of configuration helpers
+// for [Scan], [Lines] and [Runes].
+type ReaderOptionFunc func(*ReaderOptions)
+
+// WithReaderBufferSize is a helper for configuring the initial size of
+// the buffer used for reading in [Scan], [Lines] and [Runes].
+func WithReaderBufferSize(bufferSize int) ReaderOptionFunc {
+	return func(o *ReaderOptions) {
+		o.BufferSize = bufferSize
+	}
+}
+
+// WithReaderMaxTokenSize is a helper for configuring the maximum size
+// of a token in [Scan] and [Lines].
+func WithReaderMaxTokenSize(maxTokenSize int) ReaderOptionFunc {
+	return func(o *ReaderOptions) {
+		o.MaxTokenSize = maxTokenSize
+	}
+}
+
+// newReaderOptions returns the default [ReaderOptions] with the given
+// configuration helpers applied.
+func newReaderOptions(opts ...ReaderOptionFunc) ReaderOptions {
+	options := ReaderOptions{
+		BufferSize:   4096,
+		MaxTokenSize: bufio.MaxScanTokenSize,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// Scan allows looping over tokens read from an [io.Reader] and split
+// using a given [bufio.SplitFunc]. If reading fails, the error is
+// yielded along with an empty token and looping ends.
+func Scan(
+	r io.Reader,
+	split bufio.SplitFunc,
+	opts ...ReaderOptionFunc,
+) iter.Seq2[string, error] {
+	options := newReaderOptions(opts...)
+
+	return func(yield func(string, error) bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, options.BufferSize), options.MaxTokenSize)
+		scanner.Split(split)
+
+		for scanner.Scan() {
+			if !yield(scanner.Text(), nil) {
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			yield("", err)
+		}
+	}
+}
+
+// Lines allows looping over lines read from an [io.Reader], with
+// trailing end-of-line markers removed. If reading fails, the error is
+// yielded along with an empty line and looping ends.
+func Lines(r io.Reader, opts ...ReaderOptionFunc) iter.Seq2[string, error] {
+	return Scan(r, bufio.ScanLines, opts...)
+}
+
+// Runes allows looping over UTF-8 encoded runes read from an
+// [io.Reader]. Invalid encodings are yielded as
+// [unicode/utf8.RuneError]. If reading fails, the error is yielded
+// along with a zero rune and looping ends.
+func Runes(r io.Reader, opts ...ReaderOptionFunc) iter.Seq2[rune, error] {
+	options := newReaderOptions(opts...)
+
+	return func(yield func(rune, error) bool) {
+		reader := bufio.NewReaderSize(r, options.BufferSize)
+
+		for {
+			char, _, err := reader.ReadRune()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil {
+				yield(0, err)
+
+				return
+			}
+
+			if !yield(char, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Chunks allows looping over chunks of bytes of a given size read from
+// an [io.Reader]. The last chunk may be smaller than the given size.
+// The size must be positive. If reading fails, the error is yielded
+// along with the bytes read before the failure and looping ends.
+func Chunks(r io.Reader, size int) iter.Seq2[[]byte, error] {
+	if size <= 0 {
+		panic("size must be positive")
+	}
+
+	return func(yield func([]byte, error) bool) {
+		for {
+			chunk := make([]byte, size)
+
+			n, err := io.ReadFull(r, chunk)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				yield(chunk[:n], nil)
+
+				return
+			}
+
+			if err != nil {
+				yield(chunk[:n], err)
+
+				return
+			}
+
+			if !yield(chunk, nil) {
+				return
+			}
+		}
+	}
+}
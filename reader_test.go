@@ -0,0 +1,242 @@
+package gloop_test
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf8"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+var errReaderTest = errors.New("reader test error")
+
+func TestWithReaderBufferSize(t *testing.T) {
+	bufferSize := 42
+	options := gloop.ReaderOptions{}
+	gloop.WithReaderBufferSize(bufferSize)(&options)
+	require.Equal(t, bufferSize, options.BufferSize)
+}
+
+func TestWithReaderMaxTokenSize(t *testing.T) {
+	maxTokenSize := 42
+	options := gloop.ReaderOptions{}
+	gloop.WithReaderMaxTokenSize(maxTokenSize)(&options)
+	require.Equal(t, maxTokenSize, options.MaxTokenSize)
+}
+
+func TestScanWords(t *testing.T) {
+	r := strings.NewReader("Fizz Buzz\nFizzBuzz  42")
+	wantWords := []string{"Fizz", "Buzz", "FizzBuzz", "42"}
+	i := 0
+
+	for word, err := range gloop.Scan(r, bufio.ScanWords) {
+		require.NoError(t, err)
+		require.Equal(t, wantWords[i], word)
+
+		i++
+	}
+
+	require.Equal(t, len(wantWords), i)
+}
+
+func TestScanError(t *testing.T) {
+	r := io.MultiReader(strings.NewReader("Fizz Buzz "), iotest.ErrReader(errReaderTest))
+	wantWords := []string{"Fizz", "Buzz"}
+	i := 0
+	var gotErr error
+
+	for word, err := range gloop.Scan(r, bufio.ScanWords) {
+		if err != nil {
+			gotErr = err
+
+			continue
+		}
+
+		require.Equal(t, wantWords[i], word)
+
+		i++
+	}
+
+	require.Equal(t, len(wantWords), i)
+	require.ErrorIs(t, gotErr, errReaderTest)
+}
+
+func TestScanBreak(t *testing.T) {
+	r := strings.NewReader("Fizz Buzz")
+
+	for word, err := range gloop.Scan(r, bufio.ScanWords) {
+		require.NoError(t, err)
+		require.Equal(t, "Fizz", word)
+
+		break
+	}
+}
+
+func TestLines(t *testing.T) {
+	r := strings.NewReader("Fizz\r\nBuzz\n\nFizzBuzz")
+	wantLines := []string{"Fizz", "Buzz", "", "FizzBuzz"}
+	i := 0
+
+	for line, err := range gloop.Lines(r) {
+		require.NoError(t, err)
+		require.Equal(t, wantLines[i], line)
+
+		i++
+	}
+
+	require.Equal(t, len(wantLines), i)
+}
+
+func TestLinesTooLong(t *testing.T) {
+	r := strings.NewReader(strings.Repeat("a", 100) + "\nFizz")
+	var gotErr error
+
+	for _, err := range gloop.Lines(r, gloop.WithReaderBufferSize(16), gloop.WithReaderMaxTokenSize(64)) {
+		gotErr = err
+	}
+
+	require.ErrorIs(t, gotErr, bufio.ErrTooLong)
+}
+
+func TestLinesLongMaxTokenSize(t *testing.T) {
+	longLine := strings.Repeat("a", 100)
+	r := strings.NewReader(longLine + "\nFizz")
+	wantLines := []string{longLine, "Fizz"}
+	i := 0
+
+	for line, err := range gloop.Lines(r, gloop.WithReaderBufferSize(16), gloop.WithReaderMaxTokenSize(128)) {
+		require.NoError(t, err)
+		require.Equal(t, wantLines[i], line)
+
+		i++
+	}
+
+	require.Equal(t, len(wantLines), i)
+}
+
+func TestRunes(t *testing.T) {
+	s := "Fizz😀Buzz"
+	r := iotest.OneByteReader(strings.NewReader(s))
+	i := 0
+
+	for char, err := range gloop.Runes(r, gloop.WithReaderBufferSize(16)) {
+		require.NoError(t, err)
+		require.Equal(t, []rune(s)[i], char)
+
+		i++
+	}
+
+	require.Equal(t, utf8.RuneCountInString(s), i)
+}
+
+func TestRunesInvalid(t *testing.T) {
+	r := strings.NewReader("a\xffb")
+	wantRunes := []rune{'a', utf8.RuneError, 'b'}
+	i := 0
+
+	for char, err := range gloop.Runes(r) {
+		require.NoError(t, err)
+		require.Equal(t, wantRunes[i], char)
+
+		i++
+	}
+
+	require.Equal(t, len(wantRunes), i)
+}
+
+func TestRunesError(t *testing.T) {
+	r := io.MultiReader(strings.NewReader("ab"), iotest.ErrReader(errReaderTest))
+	wantRunes := []rune{'a', 'b', 0}
+	wantErrs := []error{nil, nil, errReaderTest}
+	i := 0
+
+	for char, err := range gloop.Runes(r) {
+		require.Equal(t, wantRunes[i], char)
+		require.ErrorIs(t, err, wantErrs[i])
+
+		i++
+	}
+
+	require.Equal(t, len(wantRunes), i)
+}
+
+func TestRunesBreak(t *testing.T) {
+	r := strings.NewReader("ab")
+
+	for char, err := range gloop.Runes(r) {
+		require.NoError(t, err)
+		require.Equal(t, 'a', char)
+
+		break
+	}
+}
+
+func TestChunksDivisibleLength(t *testing.T) {
+	r := strings.NewReader("FizzBuzz")
+	wantChunks := []string{"Fizz", "Buzz"}
+	i := 0
+
+	for chunk, err := range gloop.Chunks(r, 4) {
+		require.NoError(t, err)
+		require.Equal(t, wantChunks[i], string(chunk))
+
+		i++
+	}
+
+	require.Equal(t, len(wantChunks), i)
+}
+
+func TestChunksIndivisibleLength(t *testing.T) {
+	r := iotest.HalfReader(strings.NewReader("FizzBuzz"))
+	wantChunks := []string{"Fiz", "zBu", "zz"}
+	i := 0
+
+	for chunk, err := range gloop.Chunks(r, 3) {
+		require.NoError(t, err)
+		require.Equal(t, wantChunks[i], string(chunk))
+
+		i++
+	}
+
+	require.Equal(t, len(wantChunks), i)
+}
+
+func TestChunksError(t *testing.T) {
+	r := io.MultiReader(strings.NewReader("FizzBu"), iotest.ErrReader(errReaderTest))
+	wantChunks := []string{"Fizz", "Bu"}
+	wantErrs := []error{nil, errReaderTest}
+	i := 0
+
+	for chunk, err := range gloop.Chunks(r, 4) {
+		require.Equal(t, wantChunks[i], string(chunk))
+		require.ErrorIs(t, err, wantErrs[i])
+
+		i++
+	}
+
+	require.Equal(t, len(wantChunks), i)
+}
+
+func TestChunksBreak(t *testing.T) {
+	r := strings.NewReader("FizzBuzz")
+
+	for chunk, err := range gloop.Chunks(r, 4) {
+		require.NoError(t, err)
+		require.Equal(t, "Fizz", string(chunk))
+
+		break
+	}
+}
+
+func TestChunksZeroSizePanics(t *testing.T) {
+	require.Panics(t, func() {
+		for range gloop.Chunks(strings.NewReader("FizzBuzz"), 0) {
+			t.Fatal("expected no iteration")
+		}
+	})
+}
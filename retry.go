@@ -0,0 +1,124 @@
+package gloop
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+)
+
+// RetryPolicy defines how functions that return errors are retried in
+// [ParallelizeErr] and [ParallelizeErr2]. Recovered panics are not
+// retried, and retrying stops once the context is cancelled.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times the function is
+	// called for each value, including the first attempt. It must be
+	// positive.
+	MaxAttempts int
+	// InitialBackoff is the time waited before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum time waited before a retry. If zero,
+	// there is no maximum.
+	MaxBackoff time.Duration
+	// Multiplier is the factor by which the backoff grows after each
+	// retry. If zero, the backoff doubles after each retry.
+	Multiplier float64
+	// Jitter is the fraction of each backoff that is randomized, from
+	// 0 for no randomization to 1 for a backoff anywhere between zero
+	// and its full duration.
+	Jitter float64
+	// Retryable reports whether an error should be retried. If nil,
+	// all errors are retried.
+	Retryable func(error) bool
+	// Report collects the number of attempts and the final error for
+	// each value. If nil, nothing is collected.
+	Report *RetryReport
+}
+
+// backoff returns the time to wait before retrying after a given
+// number of attempts.
+func (p RetryPolicy) backoff(attempts int) time.Duration {
+	if p.InitialBackoff == 0 {
+		return 0
+	}
+
+	multiplier := p.Multiplier
+	if multiplier == 0 {
+		multiplier = 2
+	}
+
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempts-1))
+	if p.MaxBackoff != 0 {
+		backoff = min(backoff, float64(p.MaxBackoff))
+	}
+
+	backoff -= backoff * p.Jitter * rand.Float64()
+
+	// backoffs that overflow a duration are clamped to the longest
+	// duration, as converting them would wrap around.
+	if backoff >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+
+	return time.Duration(backoff)
+}
+
+// retryable reports whether an error should be retried after a given
+// number of attempts.
+func (p RetryPolicy) retryable(err error, attempts int) bool {
+	if attempts >= p.MaxAttempts {
+		return false
+	}
+
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+// RetryResult is the outcome of running a function with a
+// [RetryPolicy] for a single value.
+type RetryResult struct {
+	// Index is the position of the value in the sequence.
+	Index int
+	// Attempts is the number of times the function was called.
+	Attempts int
+	// Err is the error returned by the last attempt, or nil if it
+	// succeeded.
+	Err error
+}
+
+// RetryReport collects a [RetryResult] for each value processed with
+// a [RetryPolicy]. It is reset each time processing starts. The zero
+// value is ready to use.
+type RetryReport struct {
+	mu      sync.Mutex
+	results []RetryResult
+}
+
+// Results returns the collected results, ordered by index.
+func (r *RetryReport) Results() []RetryResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := slices.Clone(r.results)
+	slices.SortFunc(results, func(a, b RetryResult) int {
+		return a.Index - b.Index
+	})
+
+	return results
+}
+
+// reset removes all collected results.
+func (r *RetryReport) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.results = nil
+}
+
+// add collects a result.
+func (r *RetryReport) add(result RetryResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.results = append(r.results, result)
+}
@@ -0,0 +1,348 @@
+package gloop_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+var errRetryTest = errors.New("retry test error")
+
+func TestWithParallelizeRetry(t *testing.T) {
+	options := gloop.ParallelizeOptions{}
+	gloop.WithParallelizeRetry(gloop.RetryPolicy{MaxAttempts: 3})(&options)
+
+	require.NotNil(t, options.Retry)
+	require.Equal(t, 3, options.Retry.MaxAttempts)
+}
+
+func TestRetryReportEmpty(t *testing.T) {
+	var report gloop.RetryReport
+
+	require.Empty(t, report.Results())
+}
+
+func TestParallelizeErrRetryBackoff(t *testing.T) {
+	clock := newFakeClock(time.Now())
+	report := &gloop.RetryReport{}
+
+	var attempts atomic.Int64
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Slice([]string{"Fizz"}), func(_ context.Context, _ string) error {
+			attempts.Add(1)
+
+			return errRetryTest
+		},
+			gloop.WithParallelizeClock(clock),
+			gloop.WithParallelizeRetry(gloop.RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: time.Millisecond * 100,
+				Report:         report,
+			}),
+		)
+	}()
+
+	clock.WaitForAfter(t)
+	require.EqualValues(t, 1, attempts.Load())
+	clock.Advance(time.Millisecond * 100)
+
+	clock.WaitForAfter(t)
+	require.EqualValues(t, 2, attempts.Load())
+	clock.Advance(time.Millisecond * 199)
+
+	select {
+	case <-done:
+		t.Fatal("retried before backoff elapsed")
+	case <-time.After(time.Millisecond * 20):
+	}
+
+	clock.Advance(time.Millisecond)
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, errRetryTest)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 3, attempts.Load())
+	require.Equal(t, []gloop.RetryResult{
+		{Index: 0, Attempts: 3, Err: errRetryTest},
+	}, report.Results())
+}
+
+func TestParallelizeErrRetryMaxBackoff(t *testing.T) {
+	clock := newFakeClock(time.Now())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Slice([]string{"Fizz"}), func(_ context.Context, _ string) error {
+			return errRetryTest
+		},
+			gloop.WithParallelizeClock(clock),
+			gloop.WithParallelizeRetry(gloop.RetryPolicy{
+				MaxAttempts:    4,
+				InitialBackoff: time.Millisecond * 100,
+				MaxBackoff:     time.Millisecond * 150,
+				Multiplier:     10,
+			}),
+		)
+	}()
+
+	for range 3 {
+		clock.WaitForAfter(t)
+		clock.Advance(time.Millisecond * 150)
+	}
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, errRetryTest)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestParallelizeErrRetryBackoffOverflow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	clock := newFakeClock(time.Now())
+	report := &gloop.RetryReport{}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Slice([]string{"Fizz"}), func(_ context.Context, _ string) error {
+			return errRetryTest
+		},
+			gloop.WithParallelizeContext(ctx),
+			gloop.WithParallelizeClock(clock),
+			gloop.WithParallelizeRetry(gloop.RetryPolicy{
+				MaxAttempts:    100,
+				InitialBackoff: time.Second,
+				Report:         report,
+			}),
+		)
+	}()
+
+	// the backoff after the 35th attempt overflows a duration.
+	for i := range 34 {
+		clock.WaitForAfter(t)
+		clock.Advance(time.Second << i)
+	}
+
+	clock.WaitForAfter(t)
+	cancel()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, errRetryTest)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.Equal(t, []gloop.RetryResult{
+		{Index: 0, Attempts: 35, Err: errRetryTest},
+	}, report.Results())
+}
+
+func TestParallelizeErrRetryZeroBackoff(t *testing.T) {
+	report := &gloop.RetryReport{}
+
+	err := gloop.ParallelizeErr(gloop.Slice([]string{"Fizz"}), func(_ context.Context, _ string) error {
+		return errRetryTest
+	}, gloop.WithParallelizeRetry(gloop.RetryPolicy{
+		MaxAttempts: 2000,
+		Report:      report,
+	}))
+	require.ErrorIs(t, err, errRetryTest)
+	require.Equal(t, []gloop.RetryResult{
+		{Index: 0, Attempts: 2000, Err: errRetryTest},
+	}, report.Results())
+}
+
+func TestParallelizeErrRetryJitter(t *testing.T) {
+	clock := newFakeClock(time.Now())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Slice([]string{"Fizz"}), func(_ context.Context, _ string) error {
+			return errRetryTest
+		},
+			gloop.WithParallelizeClock(clock),
+			gloop.WithParallelizeRetry(gloop.RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: time.Millisecond * 100,
+				Multiplier:     1,
+				Jitter:         1,
+			}),
+		)
+	}()
+
+	for range 2 {
+		clock.WaitForAfter(t)
+		clock.Advance(time.Millisecond * 100)
+	}
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, errRetryTest)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+}
+
+func TestParallelizeErrRetrySucceeds(t *testing.T) {
+	clock := newFakeClock(time.Now())
+	report := &gloop.RetryReport{}
+	values := []string{"Fizz", "Buzz", "Bazz"}
+
+	var buzzAttempts atomic.Int64
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Slice(values), func(_ context.Context, v string) error {
+			if v == "Buzz" && buzzAttempts.Add(1) < 3 {
+				return errRetryTest
+			}
+
+			return nil
+		},
+			gloop.WithParallelizeClock(clock),
+			gloop.WithParallelizeRetry(gloop.RetryPolicy{
+				MaxAttempts:    5,
+				InitialBackoff: time.Second,
+				Report:         report,
+			}),
+		)
+	}()
+
+	clock.WaitForAfter(t)
+	clock.Advance(time.Second)
+	clock.WaitForAfter(t)
+	clock.Advance(time.Second * 2)
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.Equal(t, []gloop.RetryResult{
+		{Index: 0, Attempts: 1, Err: nil},
+		{Index: 1, Attempts: 3, Err: nil},
+		{Index: 2, Attempts: 1, Err: nil},
+	}, report.Results())
+}
+
+func TestParallelizeErrRetryNotRetryable(t *testing.T) {
+	errPermanent := errors.New("permanent error")
+	report := &gloop.RetryReport{}
+
+	err := gloop.ParallelizeErr(gloop.Slice([]string{"Fizz"}), func(_ context.Context, _ string) error {
+		return errPermanent
+	}, gloop.WithParallelizeRetry(gloop.RetryPolicy{
+		MaxAttempts: 3,
+		Retryable: func(err error) bool {
+			return !errors.Is(err, errPermanent)
+		},
+		Report: report,
+	}))
+
+	require.ErrorIs(t, err, errPermanent)
+	require.Equal(t, []gloop.RetryResult{
+		{Index: 0, Attempts: 1, Err: errPermanent},
+	}, report.Results())
+}
+
+func TestParallelizeErrRetryCancelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	clock := newFakeClock(time.Now())
+	report := &gloop.RetryReport{}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Slice([]string{"Fizz"}), func(_ context.Context, _ string) error {
+			return errRetryTest
+		},
+			gloop.WithParallelizeContext(ctx),
+			gloop.WithParallelizeClock(clock),
+			gloop.WithParallelizeRetry(gloop.RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: time.Second,
+				Report:         report,
+			}),
+		)
+	}()
+
+	clock.WaitForAfter(t)
+	cancel()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, errRetryTest)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.Equal(t, []gloop.RetryResult{
+		{Index: 0, Attempts: 1, Err: errRetryTest},
+	}, report.Results())
+}
+
+func TestParallelizeErrRetryPanic(t *testing.T) {
+	report := &gloop.RetryReport{}
+
+	var attempts atomic.Int64
+
+	err := gloop.ParallelizeErr(gloop.Slice([]string{"Fizz"}), func(_ context.Context, v string) error {
+		attempts.Add(1)
+
+		panic(v)
+	},
+		gloop.WithParallelizeRecoverPanics(true),
+		gloop.WithParallelizeRetry(gloop.RetryPolicy{
+			MaxAttempts: 3,
+			Report:      report,
+		}),
+	)
+
+	var panicErr *gloop.PanicError
+	require.ErrorAs(t, err, &panicErr)
+	require.EqualValues(t, 1, attempts.Load())
+	require.Len(t, report.Results(), 1)
+	require.Equal(t, 1, report.Results()[0].Attempts)
+}
+
+func TestParallelizeErrRetryReportReset(t *testing.T) {
+	report := &gloop.RetryReport{}
+	policy := gloop.RetryPolicy{
+		MaxAttempts: 1,
+		Report:      report,
+	}
+
+	for range 2 {
+		err := gloop.ParallelizeErr(gloop.Slice([]int{3, 1, 4}), func(_ context.Context, _ int) error {
+			return nil
+		}, gloop.WithParallelizeRetry(policy))
+		require.NoError(t, err)
+		require.Len(t, report.Results(), 3)
+	}
+}
+
+func TestParallelizeErrRetryInvalidMaxAttemptsPanics(t *testing.T) {
+	require.Panics(t, func() {
+		_ = gloop.ParallelizeErr(gloop.Slice([]int{3}), func(_ context.Context, _ int) error {
+			return nil
+		}, gloop.WithParallelizeRetry(gloop.RetryPolicy{}))
+	})
+}
@@ -0,0 +1,79 @@
+package gloop
+
+// ring is a circular buffer of values with a maximum capacity. Slots
+// are allocated as values are pushed, up to the capacity.
+type ring[V any] struct {
+	values   []V
+	capacity int
+	head     int
+	length   int
+}
+
+// newRing creates a new ring with a given capacity.
+func newRing[V any](capacity int) *ring[V] {
+	return &ring[V]{
+		values:   nil,
+		capacity: capacity,
+		head:     0,
+		length:   0,
+	}
+}
+
+// Len returns the number of values in the ring.
+func (r *ring[V]) Len() int {
+	return r.length
+}
+
+// Full returns whether or not the ring is filled to capacity.
+func (r *ring[V]) Full() bool {
+	return r.length == r.capacity
+}
+
+// At returns the value at a given position, counting from the oldest
+// value in the ring.
+func (r *ring[V]) At(i int) V {
+	return r.values[(r.head+i)%len(r.values)]
+}
+
+// Push adds a value to the ring. The ring must not be full.
+func (r *ring[V]) Push(value V) {
+	if r.length < len(r.values) {
+		r.values[(r.head+r.length)%len(r.values)] = value
+		r.length++
+
+		return
+	}
+
+	// every slot is in use, so the ring grows, moving its values to the
+	// start of a larger slice if they wrap around or fill it.
+	if r.head != 0 || len(r.values) == cap(r.values) {
+		values := make([]V, r.length, min(r.capacity, max(1, 2*r.length)))
+		for i := range r.length {
+			values[i] = r.At(i)
+		}
+
+		r.values = values
+		r.head = 0
+	}
+
+	r.values = append(r.values, value)
+	r.length++
+}
+
+// Pop removes the oldest value from the ring. The ring must not be
+// empty.
+func (r *ring[V]) Pop() {
+	var zero V
+	r.values[r.head] = zero
+	r.head = (r.head + 1) % len(r.values)
+	r.length--
+}
+
+// Reset removes all values from the ring.
+func (r *ring[V]) Reset() {
+	for r.length > 0 {
+		r.Pop()
+	}
+
+	r.head = 0
+}
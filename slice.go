@@ -1,6 +1,7 @@
 package gloop
 
 import (
+	"errors"
 	"iter"
 )
 
@@ -43,3 +44,30 @@ func ToSlice2[K, V any](seq iter.Seq2[K, V]) ([]K, []V) {
 
 	return keys, values
 }
+
+// ToSliceErr converts an [iter.Seq2] sequence of values and errors to a
+// slice of values. By default, converting ends at the first error,
+// which is returned along with the values before it. If configured to
+// collect errors, values that come with an error are skipped and all
+// errors are returned joined together.
+func ToSliceErr[V any](seq iter.Seq2[V, error], opts ...ErrorOptionFunc) ([]V, error) {
+	options := newErrorOptions(opts...)
+	values := make([]V, 0)
+	errs := make([]error, 0)
+
+	for value, err := range seq {
+		if err != nil {
+			if !options.Collect {
+				return values, err
+			}
+
+			errs = append(errs, err)
+
+			continue
+		}
+
+		values = append(values, value)
+	}
+
+	return values, errors.Join(errs...)
+}
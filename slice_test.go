@@ -1,6 +1,7 @@
 package gloop_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/alvii147/gloop"
@@ -76,3 +77,38 @@ func TestToSlice2(t *testing.T) {
 	require.Equal(t, []int{0, 1, 2}, keys)
 	require.Equal(t, []int{3, 4, 5}, values)
 }
+
+func TestToSliceErr(t *testing.T) {
+	values := []int{3, 4, 5}
+
+	gotValues, err := gloop.ToSliceErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(make([]error, len(values)))),
+	)
+	require.NoError(t, err)
+	require.Equal(t, values, gotValues)
+}
+
+func TestToSliceErrStop(t *testing.T) {
+	errSource := errors.New("source error")
+	values := []int{3, 4, 5, 6}
+	errs := []error{nil, nil, errSource, nil}
+
+	gotValues, err := gloop.ToSliceErr(gloop.Zip(gloop.Slice(values), gloop.Slice(errs)))
+	require.ErrorIs(t, err, errSource)
+	require.Equal(t, []int{3, 4}, gotValues)
+}
+
+func TestToSliceErrCollect(t *testing.T) {
+	errSource1 := errors.New("source error 1")
+	errSource2 := errors.New("source error 2")
+	values := []int{3, 4, 5, 6}
+	errs := []error{nil, errSource1, nil, errSource2}
+
+	gotValues, err := gloop.ToSliceErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(errs)),
+		gloop.WithErrorCollect(true),
+	)
+	require.ErrorIs(t, err, errSource1)
+	require.ErrorIs(t, err, errSource2)
+	require.Equal(t, []int{3, 5}, gotValues)
+}
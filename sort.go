@@ -18,18 +18,15 @@ func Sort[V cmp.Ordered](seq iter.Seq[V], ascending bool) iter.Seq[V] {
 type SortByComparisonFunc[V any] func(V, V) bool
 
 // SortByComparison allows looping over an [iter.Seq] sequence in
-// sorted order using a comparison function.
+// sorted order using a comparison function. The sort is stable, so
+// equal values keep their order in the sequence.
 func SortByComparison[V any](
 	seq iter.Seq[V],
 	less SortByComparisonFunc[V],
 	ascending bool,
 ) iter.Seq[V] {
 	values := ToSlice(seq)
-	sort.Slice(values, func(i int, j int) bool {
-		isLess := less(values[i], values[j])
-
-		return (ascending && isLess) || (!ascending && !isLess)
-	})
+	sortStable(values, sortLess(less, ascending))
 
 	return func(yield func(V) bool) {
 		for _, value := range values {
@@ -45,7 +42,8 @@ func SortByComparison[V any](
 type SortByComparison2Func[K, V any] func(K, V, K, V) bool
 
 // SortByComparison2 allows looping over an [iter.Seq2] sequence in
-// sorted order using a comparison function.
+// sorted order using a comparison function. The sort is stable, so
+// equal keys and values keep their order in the sequence.
 func SortByComparison2[K, V any](
 	seq iter.Seq2[K, V],
 	less SortByComparison2Func[K, V],
@@ -65,7 +63,8 @@ func SortByComparison2[K, V any](
 type SortByRankFunc[V any, R cmp.Ordered] func(V) R
 
 // SortByRank allows looping over an [iter.Seq] sequence in sorted
-// order using a ranking function.
+// order using a ranking function. The sort is stable, so values of
+// equal rank keep their order in the sequence.
 func SortByRank[V any, R cmp.Ordered](
 	seq iter.Seq[V],
 	rank SortByRankFunc[V, R],
@@ -81,7 +80,8 @@ func SortByRank[V any, R cmp.Ordered](
 type SortByRank2Func[K, V any, R cmp.Ordered] func(K, V) R
 
 // SortByRank2 allows looping over an [iter.Seq2] sequence in sorted
-// order using a ranking function.
+// order using a ranking function. The sort is stable, so keys and
+// values of equal rank keep their order in the sequence.
 func SortByRank2[K, V any, R cmp.Ordered](
 	seq iter.Seq2[K, V],
 	rank SortByRank2Func[K, V, R],
@@ -91,3 +91,23 @@ func SortByRank2[K, V any, R cmp.Ordered](
 		return rank(key1, value1) < rank(key2, value2)
 	}, ascending)
 }
+
+// sortLess returns a comparison function that orders values in
+// ascending or descending order using a given comparison function.
+func sortLess[V any](less SortByComparisonFunc[V], ascending bool) SortByComparisonFunc[V] {
+	if ascending {
+		return less
+	}
+
+	return func(value1 V, value2 V) bool {
+		return less(value2, value1)
+	}
+}
+
+// sortStable sorts a slice in place using a given comparison function,
+// keeping equal values in their original order.
+func sortStable[V any](values []V, less SortByComparisonFunc[V]) {
+	sort.SliceStable(values, func(i int, j int) bool {
+		return less(values[i], values[j])
+	})
+}
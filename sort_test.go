@@ -173,6 +173,39 @@ func TestSortByComparisonBreak(t *testing.T) {
 	require.Equal(t, len(wantValues), i)
 }
 
+func TestSortByComparisonStable(t *testing.T) {
+	values := []string{"Fiz", "Buzzz", "Baz", "Bu", "Fizzz", "Bz"}
+
+	testcases := map[string]struct {
+		ascending  bool
+		wantValues []string
+	}{
+		"Ascending": {
+			ascending:  true,
+			wantValues: []string{"Bu", "Bz", "Fiz", "Baz", "Buzzz", "Fizzz"},
+		},
+		"Descending": {
+			ascending:  false,
+			wantValues: []string{"Buzzz", "Fizzz", "Fiz", "Baz", "Bu", "Bz"},
+		},
+	}
+
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			gotValues := gloop.ToSlice(gloop.SortByComparison(
+				gloop.Slice(values),
+				func(s1 string, s2 string) bool {
+					return len(s1) < len(s2)
+				},
+				testcase.ascending,
+			))
+			require.Equal(t, testcase.wantValues, gotValues)
+		})
+	}
+}
+
 func TestSortByComparison2Ascending(t *testing.T) {
 	m := map[int]int{
 		3:  -1,
@@ -0,0 +1,118 @@
+package gloop
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// ThrottleOptions defines configurable options for [Throttle].
+type ThrottleOptions struct {
+	// Burst is the maximum number of values yielded without delay
+	// after a period of inactivity.
+	Burst int
+	// Clock is used to wait between values.
+	Clock Clock
+}
+
+// ThrottleOptionFunc is the function signature of configuration
+// helpers for [Throttle].
+type ThrottleOptionFunc func(*ThrottleOptions)
+
+// WithThrottleBurst is a helper for configuring the maximum number of
+// values yielded without delay in [Throttle].
+func WithThrottleBurst(burst int) ThrottleOptionFunc {
+	return func(o *ThrottleOptions) {
+		o.Burst = burst
+	}
+}
+
+// WithThrottleClock is a helper for configuring the clock in
+// [Throttle].
+func WithThrottleClock(clock Clock) ThrottleOptionFunc {
+	return func(o *ThrottleOptions) {
+		o.Clock = clock
+	}
+}
+
+// Throttle allows looping over an [iter.Seq] sequence at no more than
+// a given rate of values per second, delaying values that would exceed
+// it. The rate and burst must be positive.
+func Throttle[V any](seq iter.Seq[V], rate float64, opts ...ThrottleOptionFunc) iter.Seq[V] {
+	options := ThrottleOptions{
+		Burst: 1,
+		Clock: SystemClock{},
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	validateTokenBucket(rate, options.Burst)
+
+	return func(yield func(V) bool) {
+		bucket := newTokenBucket(rate, options.Burst, options.Clock)
+
+		for value := range seq {
+			bucket.wait(context.Background())
+
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// tokenBucket limits the rate of events, allowing bursts of events up
+// to its capacity.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	clock  Clock
+}
+
+// validateTokenBucket panics if a given rate or burst is invalid.
+func validateTokenBucket(rate float64, burst int) {
+	if rate <= 0 {
+		panic("rate must be positive")
+	}
+
+	if burst <= 0 {
+		panic("burst must be positive")
+	}
+}
+
+// newTokenBucket creates a full [tokenBucket] with a given rate of
+// events per second and burst.
+func newTokenBucket(rate float64, burst int, clock Clock) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   clock.Now(),
+		clock:  clock,
+	}
+}
+
+// wait waits until an event is allowed and takes a token for it. It
+// returns false if the context is cancelled first.
+func (b *tokenBucket) wait(ctx context.Context) bool {
+	for {
+		now := b.clock.Now()
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+
+			return true
+		}
+
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		if !sleep(ctx, b.clock, delay) {
+			return false
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithThrottleBurst(t *testing.T) {
+	options := gloop.ThrottleOptions{}
+	gloop.WithThrottleBurst(3)(&options)
+
+	require.Equal(t, 3, options.Burst)
+}
+
+func TestWithThrottleClock(t *testing.T) {
+	clock := newFakeClock(time.Now())
+	options := gloop.ThrottleOptions{}
+	gloop.WithThrottleClock(clock)(&options)
+
+	require.Equal(t, clock, options.Clock)
+}
+
+func TestThrottle(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+	times := make(chan time.Time, 5)
+
+	go func() {
+		defer close(times)
+
+		for range gloop.Throttle(gloop.Interval(0, 3, 1), 10, gloop.WithThrottleClock(clock)) {
+			times <- clock.Now()
+		}
+	}()
+
+	for range 2 {
+		clock.WaitForAfter(t)
+		clock.Advance(time.Millisecond * 100)
+	}
+
+	wantTimes := []time.Time{
+		start,
+		start.Add(time.Millisecond * 100),
+		start.Add(time.Millisecond * 200),
+	}
+	gotTimes := make([]time.Time, 0)
+
+	for tm := range times {
+		gotTimes = append(gotTimes, tm)
+	}
+
+	require.Equal(t, wantTimes, gotTimes)
+}
+
+func TestThrottleBurst(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+	times := make(chan time.Time, 5)
+
+	go func() {
+		defer close(times)
+
+		for range gloop.Throttle(
+			gloop.Interval(0, 5, 1),
+			10,
+			gloop.WithThrottleBurst(2),
+			gloop.WithThrottleClock(clock),
+		) {
+			times <- clock.Now()
+		}
+	}()
+
+	for range 3 {
+		clock.WaitForAfter(t)
+		clock.Advance(time.Millisecond * 100)
+	}
+
+	wantTimes := []time.Time{
+		start,
+		start,
+		start.Add(time.Millisecond * 100),
+		start.Add(time.Millisecond * 200),
+		start.Add(time.Millisecond * 300),
+	}
+	gotTimes := make([]time.Time, 0)
+
+	for tm := range times {
+		gotTimes = append(gotTimes, tm)
+	}
+
+	require.Equal(t, wantTimes, gotTimes)
+}
+
+func TestThrottleRefill(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+	values := make([]int, 0)
+
+	for value := range gloop.Throttle(
+		gloop.Interval(0, 4, 1),
+		10,
+		gloop.WithThrottleBurst(2),
+		gloop.WithThrottleClock(clock),
+	) {
+		values = append(values, value)
+
+		if value == 1 {
+			clock.Skip(time.Second)
+		}
+	}
+
+	require.Equal(t, []int{0, 1, 2, 3}, values)
+}
+
+func TestThrottleBreak(t *testing.T) {
+	clock := newFakeClock(time.Now())
+	i := 0
+
+	for range gloop.Throttle(gloop.Interval(0, 3, 1), 10, gloop.WithThrottleClock(clock)) {
+		i++
+
+		break
+	}
+
+	require.Equal(t, 1, i)
+}
+
+func TestThrottleSystemClock(t *testing.T) {
+	values := gloop.ToSlice(gloop.Throttle(gloop.Interval(0, 3, 1), 1000))
+
+	require.Equal(t, []int{0, 1, 2}, values)
+}
+
+func TestThrottleInvalidRatePanics(t *testing.T) {
+	require.Panics(t, func() {
+		gloop.Throttle(gloop.Interval(0, 3, 1), 0)
+	})
+}
+
+func TestThrottleInvalidBurstPanics(t *testing.T) {
+	require.Panics(t, func() {
+		gloop.Throttle(gloop.Interval(0, 3, 1), 10, gloop.WithThrottleBurst(0))
+	})
+}
@@ -0,0 +1,332 @@
+package gloop
+
+import (
+	"iter"
+	"slices"
+	"time"
+)
+
+// TimeWindowOptions defines configurable options for
+// [TumblingTimeWindow], [SlidingTimeWindow] and [SessionWindow].
+type TimeWindowOptions[V any] struct {
+	// Timestamp extracts the time of each value. If nil, each value is
+	// timestamped with its arrival time according to Clock, and windows
+	// are closed by the clock even if no further values arrive. In that
+	// case the sequence is consumed on a separate goroutine, which stops
+	// the next time the sequence yields after looping has ended. If not
+	// nil, windows are closed by the timestamps of later values, which
+	// must arrive in order.
+	Timestamp TimeWindowTimestampFunc[V]
+	// Clock is used to timestamp values and to wait for windows to
+	// close when Timestamp is nil.
+	Clock Clock
+}
+
+// TimeWindowOptionFunc is the function signature of configuration
+// helpers for [TumblingTimeWindow], [SlidingTimeWindow] and
+// [SessionWindow].
+type TimeWindowOptionFunc[V any] func(*TimeWindowOptions[V])
+
+// TimeWindowTimestampFunc is the function signature of the timestamp
+// extractor used in [TumblingTimeWindow], [SlidingTimeWindow] and
+// [SessionWindow].
+type TimeWindowTimestampFunc[V any] func(V) time.Time
+
+// WithTimeWindowTimestamp is a helper for configuring the timestamp
+// extractor in [TumblingTimeWindow], [SlidingTimeWindow] and
+// [SessionWindow].
+func WithTimeWindowTimestamp[V any](timestamp TimeWindowTimestampFunc[V]) TimeWindowOptionFunc[V] {
+	return func(o *TimeWindowOptions[V]) {
+		o.Timestamp = timestamp
+	}
+}
+
+// WithTimeWindowClock is a helper for configuring the clock in
+// [TumblingTimeWindow], [SlidingTimeWindow] and [SessionWindow].
+func WithTimeWindowClock[V any](clock Clock) TimeWindowOptionFunc[V] {
+	return func(o *TimeWindowOptions[V]) {
+		o.Clock = clock
+	}
+}
+
+// timeWindower assigns timestamped values to time windows and decides
+// when windows are closed.
+type timeWindower[V any] interface {
+	// add adds a value with a given timestamp.
+	add(t time.Time, value V)
+	// advance returns all windows closed at a given time.
+	advance(now time.Time) [][]V
+	// flush returns all remaining windows.
+	flush() [][]V
+	// deadline returns the time at which the next window closes, and
+	// false if there are no open windows.
+	deadline() (time.Time, bool)
+}
+
+// TumblingTimeWindow allows looping over an [iter.Seq] sequence in
+// consecutive, non-overlapping windows of a given duration. Windows
+// are aligned to multiples of the duration and empty windows are not
+// yielded. The duration must be positive.
+func TumblingTimeWindow[V any](
+	seq iter.Seq[V],
+	size time.Duration,
+	opts ...TimeWindowOptionFunc[V],
+) iter.Seq[iter.Seq[V]] {
+	return SlidingTimeWindow(seq, size, size, opts...)
+}
+
+// SlidingTimeWindow allows looping over an [iter.Seq] sequence in
+// windows of a given duration that start at every multiple of a given
+// step. Windows overlap when the step is smaller than the duration and
+// empty windows are not yielded. The duration and step must be
+// positive.
+func SlidingTimeWindow[V any](
+	seq iter.Seq[V],
+	size time.Duration,
+	step time.Duration,
+	opts ...TimeWindowOptionFunc[V],
+) iter.Seq[iter.Seq[V]] {
+	if size <= 0 {
+		panic("size must be positive")
+	}
+
+	if step <= 0 {
+		panic("step must be positive")
+	}
+
+	return timeWindow(seq, func() timeWindower[V] {
+		return &slidingTimeWindower[V]{
+			size: size,
+			step: step,
+		}
+	}, opts...)
+}
+
+// SessionWindow allows looping over an [iter.Seq] sequence in windows
+// of activity. A window is closed once no value arrives for a given
+// gap. The gap must be positive.
+func SessionWindow[V any](
+	seq iter.Seq[V],
+	gap time.Duration,
+	opts ...TimeWindowOptionFunc[V],
+) iter.Seq[iter.Seq[V]] {
+	if gap <= 0 {
+		panic("gap must be positive")
+	}
+
+	return timeWindow(seq, func() timeWindower[V] {
+		return &sessionWindower[V]{
+			gap: gap,
+		}
+	}, opts...)
+}
+
+// timeWindow allows looping over windows of an [iter.Seq] sequence
+// assigned by a given windower.
+func timeWindow[V any](
+	seq iter.Seq[V],
+	newWindower func() timeWindower[V],
+	opts ...TimeWindowOptionFunc[V],
+) iter.Seq[iter.Seq[V]] {
+	options := TimeWindowOptions[V]{
+		Timestamp: nil,
+		Clock:     SystemClock{},
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return func(yield func(iter.Seq[V]) bool) {
+		windower := newWindower()
+
+		emit := func(windows [][]V) bool {
+			for _, window := range windows {
+				if !yield(Slice(window)) {
+					return false
+				}
+			}
+
+			return true
+		}
+
+		if options.Timestamp != nil {
+			for value := range seq {
+				t := options.Timestamp(value)
+				if !emit(windower.advance(t)) {
+					return
+				}
+
+				windower.add(t, value)
+			}
+
+			emit(windower.flush())
+
+			return
+		}
+
+		values := make(chan V)
+		done := make(chan struct{})
+
+		defer close(done)
+
+		go func() {
+			defer close(values)
+
+			for value := range seq {
+				select {
+				case values <- value:
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		for {
+			var timeout <-chan time.Time
+			if deadline, ok := windower.deadline(); ok {
+				timeout = options.Clock.After(deadline.Sub(options.Clock.Now()))
+			}
+
+			select {
+			case value, ok := <-values:
+				if !ok {
+					emit(windower.flush())
+
+					return
+				}
+
+				t := options.Clock.Now()
+				if !emit(windower.advance(t)) {
+					return
+				}
+
+				windower.add(t, value)
+			case now := <-timeout:
+				if !emit(windower.advance(now)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// slidingTimeWindower is a [timeWindower] for windows of a fixed
+// duration starting at every multiple of a fixed step.
+type slidingTimeWindower[V any] struct {
+	size   time.Duration
+	step   time.Duration
+	start  time.Time
+	times  []time.Time
+	values []V
+}
+
+func (w *slidingTimeWindower[V]) add(t time.Time, value V) {
+	if len(w.values) == 0 {
+		w.skipTo(t)
+	}
+
+	w.times = append(w.times, t)
+	w.values = append(w.values, value)
+}
+
+func (w *slidingTimeWindower[V]) advance(now time.Time) [][]V {
+	windows := make([][]V, 0)
+
+	for len(w.values) > 0 && !w.start.Add(w.size).After(now) {
+		low := w.count(w.start)
+		high := w.count(w.start.Add(w.size))
+
+		if high > low {
+			windows = append(windows, slices.Clone(w.values[low:high]))
+		}
+
+		w.start = w.start.Add(w.step)
+		n := w.count(w.start)
+		w.times = w.times[n:]
+		w.values = w.values[n:]
+
+		if len(w.values) > 0 {
+			w.skipTo(w.times[0])
+		}
+	}
+
+	return windows
+}
+
+func (w *slidingTimeWindower[V]) flush() [][]V {
+	windows := make([][]V, 0)
+
+	for len(w.values) > 0 {
+		windows = append(windows, w.advance(w.start.Add(w.size))...)
+	}
+
+	return windows
+}
+
+func (w *slidingTimeWindower[V]) deadline() (time.Time, bool) {
+	if len(w.values) == 0 {
+		return time.Time{}, false
+	}
+
+	return w.start.Add(w.size), true
+}
+
+// count returns the number of buffered values before a given time.
+func (w *slidingTimeWindower[V]) count(t time.Time) int {
+	n := 0
+	for n < len(w.times) && w.times[n].Before(t) {
+		n++
+	}
+
+	return n
+}
+
+// skipTo moves the start of the earliest open window forward to the
+// earliest window that contains a given time.
+func (w *slidingTimeWindower[V]) skipTo(t time.Time) {
+	start := t.Add(-w.size).Truncate(w.step).Add(w.step)
+	if start.After(w.start) {
+		w.start = start
+	}
+}
+
+// sessionWindower is a [timeWindower] for windows of activity
+// separated by gaps of inactivity.
+type sessionWindower[V any] struct {
+	gap    time.Duration
+	last   time.Time
+	values []V
+}
+
+func (w *sessionWindower[V]) add(t time.Time, value V) {
+	w.last = t
+	w.values = append(w.values, value)
+}
+
+func (w *sessionWindower[V]) advance(now time.Time) [][]V {
+	if len(w.values) == 0 || w.last.Add(w.gap).After(now) {
+		return nil
+	}
+
+	return w.flush()
+}
+
+func (w *sessionWindower[V]) flush() [][]V {
+	if len(w.values) == 0 {
+		return nil
+	}
+
+	windows := [][]V{w.values}
+	w.values = nil
+
+	return windows
+}
+
+func (w *sessionWindower[V]) deadline() (time.Time, bool) {
+	if len(w.values) == 0 {
+		return time.Time{}, false
+	}
+
+	return w.last.Add(w.gap), true
+}
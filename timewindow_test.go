@@ -0,0 +1,364 @@
+package gloop_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+type timeWindowEvent struct {
+	Name string
+	Time time.Time
+}
+
+func timeWindowEventTimestamp(event timeWindowEvent) time.Time {
+	return event.Time
+}
+
+func timeWindowEvents(offsets ...time.Duration) []timeWindowEvent {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := make([]timeWindowEvent, len(offsets))
+
+	for i, offset := range offsets {
+		events[i] = timeWindowEvent{
+			Name: string(rune('a' + i)),
+			Time: start.Add(offset),
+		}
+	}
+
+	return events
+}
+
+func timeWindowNames(seq func(func(timeWindowEvent) bool)) string {
+	names := ""
+	for event := range seq {
+		names += event.Name
+	}
+
+	return names
+}
+
+func TestWithTimeWindowTimestamp(t *testing.T) {
+	options := gloop.TimeWindowOptions[timeWindowEvent]{}
+	gloop.WithTimeWindowTimestamp(timeWindowEventTimestamp)(&options)
+
+	require.NotNil(t, options.Timestamp)
+
+	now := time.Now()
+	require.Equal(t, now, options.Timestamp(timeWindowEvent{Time: now}))
+}
+
+func TestWithTimeWindowClock(t *testing.T) {
+	clock := newFakeClock(time.Now())
+	options := gloop.TimeWindowOptions[int]{}
+	gloop.WithTimeWindowClock[int](clock)(&options)
+
+	require.Equal(t, clock, options.Clock)
+}
+
+func TestTumblingTimeWindowTimestamp(t *testing.T) {
+	events := timeWindowEvents(
+		0,
+		time.Millisecond*500,
+		time.Millisecond*1200,
+		time.Millisecond*3100,
+		time.Millisecond*3900,
+	)
+	wantWindows := []string{"ab", "c", "de"}
+	i := 0
+
+	for seq := range gloop.TumblingTimeWindow(
+		gloop.Slice(events),
+		time.Second,
+		gloop.WithTimeWindowTimestamp(timeWindowEventTimestamp),
+	) {
+		require.Equal(t, wantWindows[i], timeWindowNames(seq))
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestTumblingTimeWindowEmpty(t *testing.T) {
+	for range gloop.TumblingTimeWindow(
+		gloop.Slice([]timeWindowEvent{}),
+		time.Second,
+		gloop.WithTimeWindowTimestamp(timeWindowEventTimestamp),
+	) {
+		t.Fatal("expected no iteration")
+	}
+}
+
+func TestTumblingTimeWindowBreak(t *testing.T) {
+	events := timeWindowEvents(0, time.Millisecond*1200, time.Millisecond*2400)
+
+	for seq := range gloop.TumblingTimeWindow(
+		gloop.Slice(events),
+		time.Second,
+		gloop.WithTimeWindowTimestamp(timeWindowEventTimestamp),
+	) {
+		require.Equal(t, "a", timeWindowNames(seq))
+
+		break
+	}
+
+	for seq := range gloop.TumblingTimeWindow(
+		gloop.Slice(events),
+		time.Minute,
+		gloop.WithTimeWindowTimestamp(timeWindowEventTimestamp),
+	) {
+		require.Equal(t, "abc", timeWindowNames(seq))
+
+		break
+	}
+}
+
+func TestSlidingTimeWindowTimestamp(t *testing.T) {
+	events := timeWindowEvents(
+		0,
+		time.Millisecond*600,
+		time.Millisecond*1100,
+		time.Millisecond*5200,
+	)
+	wantWindows := []string{"a", "ab", "bc", "c", "d", "d"}
+	i := 0
+
+	for seq := range gloop.SlidingTimeWindow(
+		gloop.Slice(events),
+		time.Second,
+		time.Millisecond*500,
+		gloop.WithTimeWindowTimestamp(timeWindowEventTimestamp),
+	) {
+		require.Equal(t, wantWindows[i], timeWindowNames(seq))
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestSlidingTimeWindowStepGreaterThanSize(t *testing.T) {
+	events := timeWindowEvents(
+		0,
+		time.Millisecond*600,
+		time.Millisecond*1100,
+		time.Millisecond*2500,
+	)
+	wantWindows := []string{"a", "c"}
+	i := 0
+
+	for seq := range gloop.SlidingTimeWindow(
+		gloop.Slice(events),
+		time.Millisecond*500,
+		time.Second,
+		gloop.WithTimeWindowTimestamp(timeWindowEventTimestamp),
+	) {
+		require.Equal(t, wantWindows[i], timeWindowNames(seq))
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestSlidingTimeWindowZeroSizePanics(t *testing.T) {
+	require.Panics(t, func() {
+		for range gloop.SlidingTimeWindow(gloop.Slice([]int{3, 1, 4}), 0, time.Second) {
+			t.Fatal("expected no iteration")
+		}
+	})
+}
+
+func TestSlidingTimeWindowZeroStepPanics(t *testing.T) {
+	require.Panics(t, func() {
+		for range gloop.SlidingTimeWindow(gloop.Slice([]int{3, 1, 4}), time.Second, 0) {
+			t.Fatal("expected no iteration")
+		}
+	})
+}
+
+func TestSessionWindowTimestamp(t *testing.T) {
+	events := timeWindowEvents(
+		0,
+		time.Millisecond*900,
+		time.Millisecond*1800,
+		time.Millisecond*2800,
+		time.Millisecond*3700,
+		time.Millisecond*5000,
+	)
+	wantWindows := []string{"abc", "de", "f"}
+	i := 0
+
+	for seq := range gloop.SessionWindow(
+		gloop.Slice(events),
+		time.Second,
+		gloop.WithTimeWindowTimestamp(timeWindowEventTimestamp),
+	) {
+		require.Equal(t, wantWindows[i], timeWindowNames(seq))
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestSessionWindowEmpty(t *testing.T) {
+	for range gloop.SessionWindow(
+		gloop.Slice([]timeWindowEvent{}),
+		time.Second,
+		gloop.WithTimeWindowTimestamp(timeWindowEventTimestamp),
+	) {
+		t.Fatal("expected no iteration")
+	}
+}
+
+func TestSessionWindowBreak(t *testing.T) {
+	events := timeWindowEvents(0, time.Millisecond*1800, time.Millisecond*3600)
+
+	for seq := range gloop.SessionWindow(
+		gloop.Slice(events),
+		time.Second,
+		gloop.WithTimeWindowTimestamp(timeWindowEventTimestamp),
+	) {
+		require.Equal(t, "a", timeWindowNames(seq))
+
+		break
+	}
+}
+
+func TestSessionWindowZeroGapPanics(t *testing.T) {
+	require.Panics(t, func() {
+		for range gloop.SessionWindow(gloop.Slice([]int{3, 1, 4}), 0) {
+			t.Fatal("expected no iteration")
+		}
+	})
+}
+
+func TestTumblingTimeWindowArrivalTime(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	ch := make(chan string)
+
+	go func() {
+		ch <- "a"
+		clock.WaitForAfter(t)
+		clock.Advance(time.Millisecond * 500)
+
+		ch <- "b"
+		clock.WaitForAfter(t)
+		clock.Advance(time.Millisecond * 700)
+
+		ch <- "c"
+		clock.WaitForAfter(t)
+		close(ch)
+	}()
+
+	wantWindows := []string{"ab", "c"}
+	i := 0
+
+	for seq := range gloop.TumblingTimeWindow(
+		gloop.Channel(ch),
+		time.Second,
+		gloop.WithTimeWindowClock[string](clock),
+	) {
+		require.Equal(t, wantWindows[i], gloop.ToString(gloop.Transform(seq, func(s string) rune {
+			return rune(s[0])
+		})))
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestSessionWindowArrivalTime(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	ch := make(chan string)
+	closed := make(chan struct{})
+
+	go func() {
+		ch <- "a"
+		clock.WaitForAfter(t)
+		clock.Advance(time.Millisecond * 500)
+
+		ch <- "b"
+		clock.WaitForAfter(t)
+		clock.Advance(time.Second)
+
+		<-closed
+
+		ch <- "c"
+		clock.WaitForAfter(t)
+		close(ch)
+	}()
+
+	wantWindows := [][]string{{"a", "b"}, {"c"}}
+	i := 0
+
+	for seq := range gloop.SessionWindow(
+		gloop.Channel(ch),
+		time.Second,
+		gloop.WithTimeWindowClock[string](clock),
+	) {
+		require.Equal(t, wantWindows[i], gloop.ToSlice(seq))
+
+		if i == 0 {
+			close(closed)
+		}
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestSessionWindowArrivalTimeBreak(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	ch := make(chan string, 3)
+	ch <- "a"
+	ch <- "b"
+
+	go func() {
+		clock.WaitForAfter(t)
+		clock.WaitForAfter(t)
+		clock.Advance(time.Second)
+	}()
+
+	for seq := range gloop.SessionWindow(
+		gloop.Channel(ch),
+		time.Second,
+		gloop.WithTimeWindowClock[string](clock),
+	) {
+		require.Equal(t, []string{"a", "b"}, gloop.ToSlice(seq))
+
+		break
+	}
+
+	ch <- "c"
+	close(ch)
+}
+
+func TestSessionWindowArrivalTimeBreakOnValue(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	ch := make(chan string)
+
+	go func() {
+		ch <- "a"
+		clock.WaitForAfter(t)
+		clock.Skip(time.Second * 2)
+		ch <- "b"
+		close(ch)
+	}()
+
+	for seq := range gloop.SessionWindow(
+		gloop.Channel(ch),
+		time.Second,
+		gloop.WithTimeWindowClock[string](clock),
+	) {
+		require.Equal(t, []string{"a"}, gloop.ToSlice(seq))
+
+		break
+	}
+}
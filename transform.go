@@ -29,3 +29,42 @@ func Transform2[K, V, T any](seq iter.Seq2[K, V], f Transform2Func[K, V, T]) ite
 		}
 	}
 }
+
+// TransformErrFunc is the function signature of the transformation
+// function in [TransformErr].
+type TransformErrFunc[V, T any] func(V) (T, error)
+
+// TransformErr runs a given function on each value over an [iter.Seq2]
+// sequence of values and errors and allows looping over the returned
+// values and errors. Errors from the sequence are passed through
+// without running the function. Each error is yielded along with a
+// zero value, and by default looping ends at the first error.
+func TransformErr[V, T any](
+	seq iter.Seq2[V, error],
+	f TransformErrFunc[V, T],
+	opts ...ErrorOptionFunc,
+) iter.Seq2[T, error] {
+	options := newErrorOptions(opts...)
+
+	return func(yield func(T, error) bool) {
+		for value, err := range seq {
+			var result T
+			if err == nil {
+				result, err = f(value)
+			}
+
+			if err != nil {
+				var zero T
+				if !yield(zero, err) || !options.Collect {
+					return
+				}
+
+				continue
+			}
+
+			if !yield(result, nil) {
+				return
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package gloop_test
 
 import (
+	"errors"
 	"strconv"
 	"strings"
 	"testing"
@@ -147,3 +148,70 @@ func TestTransform2Break(t *testing.T) {
 
 	require.Equal(t, 1, i)
 }
+
+func TestTransformErrStop(t *testing.T) {
+	values := []string{"3", "x", "5"}
+	wantValues := []int{3, 0}
+	i := 0
+
+	for value, err := range gloop.TransformErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(make([]error, len(values)))),
+		strconv.Atoi,
+	) {
+		require.Equal(t, wantValues[i], value)
+
+		if i == 1 {
+			require.ErrorIs(t, err, strconv.ErrSyntax)
+		} else {
+			require.NoError(t, err)
+		}
+
+		i++
+	}
+
+	require.Equal(t, len(wantValues), i)
+}
+
+func TestTransformErrCollect(t *testing.T) {
+	errSource := errors.New("source error")
+	values := []string{"3", "x", "5", "6"}
+	errs := []error{nil, nil, nil, errSource}
+	wantValues := []int{3, 0, 5, 0}
+	wantErrs := []error{nil, strconv.ErrSyntax, nil, errSource}
+	i := 0
+
+	for value, err := range gloop.TransformErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(errs)),
+		strconv.Atoi,
+		gloop.WithErrorCollect(true),
+	) {
+		require.Equal(t, wantValues[i], value)
+
+		if wantErrs[i] == nil {
+			require.NoError(t, err)
+		} else {
+			require.ErrorIs(t, err, wantErrs[i])
+		}
+
+		i++
+	}
+
+	require.Equal(t, len(wantValues), i)
+}
+
+func TestTransformErrBreak(t *testing.T) {
+	values := []string{"3", "x", "5"}
+	i := 0
+
+	for range gloop.TransformErr(
+		gloop.Zip(gloop.Slice(values), gloop.Slice(make([]error, len(values)))),
+		strconv.Atoi,
+		gloop.WithErrorCollect(true),
+	) {
+		i++
+
+		break
+	}
+
+	require.Equal(t, 1, i)
+}
@@ -0,0 +1,207 @@
+package gloop
+
+import (
+	"container/list"
+	"errors"
+	"io/fs"
+	"iter"
+	"path"
+)
+
+// WalkControl controls [Walk] from inside the loop and collects the
+// errors encountered while walking. The zero value is ready to use.
+type WalkControl struct {
+	skip bool
+	errs []error
+}
+
+// SkipDir prevents [Walk] from walking into the directory that was
+// most recently yielded. SkipDir has no effect if the most recently
+// yielded entry is not a directory.
+func (c *WalkControl) SkipDir() {
+	c.skip = true
+}
+
+// Err returns the errors encountered while walking, joined together,
+// or nil if there were none.
+func (c *WalkControl) Err() error {
+	return errors.Join(c.errs...)
+}
+
+// WalkOptions defines configurable options for [Walk].
+type WalkOptions struct {
+	// MaxDepth defines the maximum depth of entries relative to the
+	// root, which is at depth 0. If nil, there is no maximum.
+	MaxDepth *int
+	// Include is a list of [path.Match] patterns. If not empty, only
+	// entries whose names match at least one pattern are yielded.
+	// Directories that do not match are still walked into.
+	Include []string
+	// Exclude is a list of [path.Match] patterns. Entries whose names
+	// match any pattern are neither yielded nor walked into.
+	Exclude []string
+	// BreadthFirst indicates whether entries are walked in
+	// breadth-first order. If false, entries are walked in depth-first
+	// order.
+	BreadthFirst bool
+	// Control is used to skip directories from inside the loop and to
+	// collect errors. If nil, errors are ignored.
+	Control *WalkControl
+}
+
+// WalkOptionFunc is the function signature of configuration helpers
+// for [Walk].
+type WalkOptionFunc func(*WalkOptions)
+
+// WithWalkMaxDepth is a helper for configuring the maximum depth in
+// [Walk].
+func WithWalkMaxDepth(maxDepth int) WalkOptionFunc {
+	return func(o *WalkOptions) {
+		o.MaxDepth = &maxDepth
+	}
+}
+
+// WithWalkInclude is a helper for configuring patterns of names to
+// include in [Walk].
+func WithWalkInclude(patterns ...string) WalkOptionFunc {
+	return func(o *WalkOptions) {
+		o.Include = append(o.Include, patterns...)
+	}
+}
+
+// WithWalkExclude is a helper for configuring patterns of names to
+// exclude in [Walk].
+func WithWalkExclude(patterns ...string) WalkOptionFunc {
+	return func(o *WalkOptions) {
+		o.Exclude = append(o.Exclude, patterns...)
+	}
+}
+
+// WithWalkBreadthFirst is a helper for configuring [Walk] to walk in
+// breadth-first order.
+func WithWalkBreadthFirst(breadthFirst bool) WalkOptionFunc {
+	return func(o *WalkOptions) {
+		o.BreadthFirst = breadthFirst
+	}
+}
+
+// WithWalkControl is a helper for configuring the [WalkControl] in
+// [Walk].
+func WithWalkControl(control *WalkControl) WalkOptionFunc {
+	return func(o *WalkOptions) {
+		o.Control = control
+	}
+}
+
+// walkEntry is an entry waiting to be visited in [Walk].
+type walkEntry struct {
+	path  string
+	entry fs.DirEntry
+	depth int
+}
+
+// Walk allows looping over the paths and entries of a file tree in a
+// given [io/fs.FS], starting with the root. Directories are read
+// lazily, entries within a directory are walked in lexical order, and
+// directories that cannot be read are not walked into. Patterns must
+// be valid [path.Match] patterns.
+func Walk(fsys fs.FS, root string, opts ...WalkOptionFunc) iter.Seq2[string, fs.DirEntry] {
+	options := WalkOptions{
+		MaxDepth:     nil,
+		Include:      nil,
+		Exclude:      nil,
+		BreadthFirst: false,
+		Control:      nil,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	for _, pattern := range append(options.Include, options.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			panic("invalid pattern " + pattern)
+		}
+	}
+
+	control := options.Control
+	if control == nil {
+		control = &WalkControl{}
+	}
+
+	matchAny := func(patterns []string, name string) bool {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return func(yield func(string, fs.DirEntry) bool) {
+		info, err := fs.Stat(fsys, root)
+		if err != nil {
+			control.errs = append(control.errs, err)
+
+			return
+		}
+
+		pending := list.New()
+		pending.PushBack(walkEntry{
+			path:  root,
+			entry: fs.FileInfoToDirEntry(info),
+			depth: 0,
+		})
+
+		for pending.Len() > 0 {
+			current := pending.Remove(pending.Front()).(walkEntry)
+
+			if current.depth > 0 && matchAny(options.Exclude, current.entry.Name()) {
+				continue
+			}
+
+			control.skip = false
+
+			if len(options.Include) == 0 || matchAny(options.Include, current.entry.Name()) {
+				if !yield(current.path, current.entry) {
+					return
+				}
+			}
+
+			if !current.entry.IsDir() || control.skip {
+				continue
+			}
+
+			if options.MaxDepth != nil && current.depth >= *options.MaxDepth {
+				continue
+			}
+
+			entries, err := fs.ReadDir(fsys, current.path)
+			if err != nil {
+				control.errs = append(control.errs, err)
+			}
+
+			children := make([]walkEntry, len(entries))
+			for i, entry := range entries {
+				children[i] = walkEntry{
+					path:  path.Join(current.path, entry.Name()),
+					entry: entry,
+					depth: current.depth + 1,
+				}
+			}
+
+			if options.BreadthFirst {
+				for _, child := range children {
+					pending.PushBack(child)
+				}
+
+				continue
+			}
+
+			for i := len(children) - 1; i >= 0; i-- {
+				pending.PushFront(children[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,382 @@
+package gloop_test
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+func newWalkFS() fstest.MapFS {
+	return fstest.MapFS{
+		"animals/cat.txt":           {Data: []byte("CAT")},
+		"animals/dog.go":            {Data: []byte("DOG")},
+		"animals/birds/chicken.txt": {Data: []byte("CHICKEN")},
+		"animals/birds/parrot.go":   {Data: []byte("PARROT")},
+		"animals/vendor/mouse.go":   {Data: []byte("MOUSE")},
+		"plants/tree.txt":           {Data: []byte("TREE")},
+		"plants/flowers/rose.go":    {Data: []byte("ROSE")},
+		"plants/flowers/tulip.txt":  {Data: []byte("TULIP")},
+		"plants/flowers/lily/.keep": {Data: nil},
+		"readme.md":                 {Data: []byte("README")},
+	}
+}
+
+func TestWalkDepthFirst(t *testing.T) {
+	fsys := newWalkFS()
+	wantPaths := make([]string, 0)
+	err := fs.WalkDir(fsys, ".", func(path string, _ fs.DirEntry, err error) error {
+		wantPaths = append(wantPaths, path)
+
+		return err
+	})
+	require.NoError(t, err)
+
+	paths := make([]string, 0)
+	for path, entry := range gloop.Walk(fsys, ".") {
+		require.NotNil(t, entry)
+		paths = append(paths, path)
+	}
+
+	require.Equal(t, wantPaths, paths)
+}
+
+func TestWalkBreadthFirst(t *testing.T) {
+	fsys := newWalkFS()
+	wantPaths := []string{
+		".",
+		"animals",
+		"plants",
+		"readme.md",
+		"animals/birds",
+		"animals/cat.txt",
+		"animals/dog.go",
+		"animals/vendor",
+		"plants/flowers",
+		"plants/tree.txt",
+		"animals/birds/chicken.txt",
+		"animals/birds/parrot.go",
+		"animals/vendor/mouse.go",
+		"plants/flowers/lily",
+		"plants/flowers/rose.go",
+		"plants/flowers/tulip.txt",
+		"plants/flowers/lily/.keep",
+	}
+
+	paths := make([]string, 0)
+	for path := range gloop.Walk(fsys, ".", gloop.WithWalkBreadthFirst(true)) {
+		paths = append(paths, path)
+	}
+
+	require.Equal(t, wantPaths, paths)
+}
+
+func TestWalkRoot(t *testing.T) {
+	fsys := newWalkFS()
+	wantPaths := []string{
+		"plants",
+		"plants/flowers",
+		"plants/flowers/lily",
+		"plants/flowers/lily/.keep",
+		"plants/flowers/rose.go",
+		"plants/flowers/tulip.txt",
+		"plants/tree.txt",
+	}
+
+	paths := make([]string, 0)
+	for path := range gloop.Walk(fsys, "plants") {
+		paths = append(paths, path)
+	}
+
+	require.Equal(t, wantPaths, paths)
+}
+
+func TestWalkFile(t *testing.T) {
+	fsys := newWalkFS()
+	paths := make([]string, 0)
+
+	for path, entry := range gloop.Walk(fsys, "readme.md") {
+		require.False(t, entry.IsDir())
+		require.Equal(t, "readme.md", entry.Name())
+		paths = append(paths, path)
+	}
+
+	require.Equal(t, []string{"readme.md"}, paths)
+}
+
+func TestWalkMaxDepth(t *testing.T) {
+	testcases := map[string]struct {
+		maxDepth  int
+		wantPaths []string
+	}{
+		"Max depth 0": {
+			maxDepth:  0,
+			wantPaths: []string{"plants"},
+		},
+		"Max depth 1": {
+			maxDepth: 1,
+			wantPaths: []string{
+				"plants",
+				"plants/flowers",
+				"plants/tree.txt",
+			},
+		},
+		"Max depth 2": {
+			maxDepth: 2,
+			wantPaths: []string{
+				"plants",
+				"plants/flowers",
+				"plants/flowers/lily",
+				"plants/flowers/rose.go",
+				"plants/flowers/tulip.txt",
+				"plants/tree.txt",
+			},
+		},
+	}
+
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			paths := make([]string, 0)
+			for path := range gloop.Walk(newWalkFS(), "plants", gloop.WithWalkMaxDepth(testcase.maxDepth)) {
+				paths = append(paths, path)
+			}
+
+			require.Equal(t, testcase.wantPaths, paths)
+		})
+	}
+}
+
+func TestWalkInclude(t *testing.T) {
+	fsys := newWalkFS()
+	wantPaths := []string{
+		"animals/birds/parrot.go",
+		"animals/dog.go",
+		"animals/vendor/mouse.go",
+		"plants/flowers/rose.go",
+		"readme.md",
+	}
+
+	paths := make([]string, 0)
+	for path := range gloop.Walk(fsys, ".", gloop.WithWalkInclude("*.go", "*.md")) {
+		paths = append(paths, path)
+	}
+
+	require.Equal(t, wantPaths, paths)
+}
+
+func TestWalkExclude(t *testing.T) {
+	fsys := newWalkFS()
+	wantPaths := []string{
+		"animals",
+		"animals/birds",
+		"animals/birds/parrot.go",
+		"animals/dog.go",
+	}
+
+	paths := make([]string, 0)
+	for path := range gloop.Walk(
+		fsys,
+		"animals",
+		gloop.WithWalkExclude("*.txt"),
+		gloop.WithWalkExclude("vendor"),
+	) {
+		paths = append(paths, path)
+	}
+
+	require.Equal(t, wantPaths, paths)
+}
+
+func TestWalkExcludeRoot(t *testing.T) {
+	fsys := newWalkFS()
+	paths := make([]string, 0)
+
+	for path := range gloop.Walk(fsys, "animals", gloop.WithWalkExclude("animals")) {
+		paths = append(paths, path)
+	}
+
+	require.Contains(t, paths, "animals")
+	require.Contains(t, paths, "animals/cat.txt")
+}
+
+func TestWalkIncludeExclude(t *testing.T) {
+	fsys := newWalkFS()
+	wantPaths := []string{
+		"animals/birds/parrot.go",
+		"animals/dog.go",
+	}
+
+	paths := make([]string, 0)
+	for path := range gloop.Walk(
+		fsys,
+		"animals",
+		gloop.WithWalkInclude("*.go"),
+		gloop.WithWalkExclude("vendor"),
+	) {
+		paths = append(paths, path)
+	}
+
+	require.Equal(t, wantPaths, paths)
+}
+
+func TestWalkSkipDir(t *testing.T) {
+	testcases := map[string]struct {
+		breadthFirst bool
+		wantPaths    []string
+	}{
+		"Depth first": {
+			breadthFirst: false,
+			wantPaths: []string{
+				".",
+				"animals",
+				"animals/birds",
+				"animals/cat.txt",
+				"animals/dog.go",
+				"animals/vendor",
+				"animals/vendor/mouse.go",
+				"plants",
+				"readme.md",
+			},
+		},
+		"Breadth first": {
+			breadthFirst: true,
+			wantPaths: []string{
+				".",
+				"animals",
+				"plants",
+				"readme.md",
+				"animals/birds",
+				"animals/cat.txt",
+				"animals/dog.go",
+				"animals/vendor",
+				"animals/vendor/mouse.go",
+			},
+		},
+	}
+
+	for name, testcase := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var control gloop.WalkControl
+			paths := make([]string, 0)
+
+			for path, entry := range gloop.Walk(
+				newWalkFS(),
+				".",
+				gloop.WithWalkBreadthFirst(testcase.breadthFirst),
+				gloop.WithWalkControl(&control),
+			) {
+				paths = append(paths, path)
+
+				if entry.Name() == "birds" || entry.Name() == "plants" || entry.Name() == "cat.txt" {
+					control.SkipDir()
+				}
+			}
+
+			require.Equal(t, testcase.wantPaths, paths)
+			require.NoError(t, control.Err())
+		})
+	}
+}
+
+func TestWalkBreak(t *testing.T) {
+	fsys := newWalkFS()
+	paths := make([]string, 0)
+
+	for path := range gloop.Walk(fsys, ".") {
+		paths = append(paths, path)
+
+		if path == "animals/birds" {
+			break
+		}
+	}
+
+	require.Equal(t, []string{".", "animals", "animals/birds"}, paths)
+}
+
+func TestWalkBreakBreadthFirst(t *testing.T) {
+	fsys := newWalkFS()
+	paths := make([]string, 0)
+
+	for path := range gloop.Walk(fsys, ".", gloop.WithWalkBreadthFirst(true)) {
+		paths = append(paths, path)
+
+		if path == "plants" {
+			break
+		}
+	}
+
+	require.Equal(t, []string{".", "animals", "plants"}, paths)
+}
+
+func TestWalkRootNotExist(t *testing.T) {
+	fsys := newWalkFS()
+	var control gloop.WalkControl
+
+	for range gloop.Walk(fsys, "fungi", gloop.WithWalkControl(&control)) {
+		require.Fail(t, "received unexpected value")
+	}
+
+	require.ErrorIs(t, control.Err(), fs.ErrNotExist)
+}
+
+func TestWalkRootNotExistWithoutControl(t *testing.T) {
+	fsys := newWalkFS()
+
+	for range gloop.Walk(fsys, "fungi") {
+		require.Fail(t, "received unexpected value")
+	}
+}
+
+type walkErrorFS struct {
+	fstest.MapFS
+}
+
+func (f walkErrorFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if name == "animals" {
+		return nil, fs.ErrPermission
+	}
+
+	return f.MapFS.ReadDir(name)
+}
+
+func TestWalkReadDirError(t *testing.T) {
+	fsys := walkErrorFS{MapFS: newWalkFS()}
+	var control gloop.WalkControl
+	wantPaths := []string{
+		".",
+		"animals",
+		"plants",
+		"plants/flowers",
+		"plants/flowers/lily",
+		"plants/flowers/lily/.keep",
+		"plants/flowers/rose.go",
+		"plants/flowers/tulip.txt",
+		"plants/tree.txt",
+		"readme.md",
+	}
+
+	paths := make([]string, 0)
+	for path := range gloop.Walk(fsys, ".", gloop.WithWalkControl(&control)) {
+		paths = append(paths, path)
+	}
+
+	require.Equal(t, wantPaths, paths)
+	require.ErrorIs(t, control.Err(), fs.ErrPermission)
+}
+
+func TestWalkInvalidPattern(t *testing.T) {
+	fsys := newWalkFS()
+
+	require.Panics(t, func() {
+		gloop.Walk(fsys, ".", gloop.WithWalkInclude("["))
+	})
+
+	require.Panics(t, func() {
+		gloop.Walk(fsys, ".", gloop.WithWalkExclude("["))
+	})
+}
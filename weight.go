@@ -0,0 +1,63 @@
+package gloop
+
+import (
+	"context"
+	"sync"
+)
+
+// weightedSemaphore limits the total weight of functions in progress.
+// It must only be acquired from one goroutine at a time.
+type weightedSemaphore struct {
+	mu       sync.Mutex
+	size     int64
+	current  int64
+	released chan struct{}
+}
+
+// newWeightedSemaphore returns a new weightedSemaphore with a given
+// maximum total weight.
+func newWeightedSemaphore(size int64) *weightedSemaphore {
+	return &weightedSemaphore{
+		size:     size,
+		current:  0,
+		released: make(chan struct{}, 1),
+	}
+}
+
+// acquire waits until a given weight fits within the maximum total
+// weight and adds it to the total. Weights above the maximum are
+// treated as the maximum, so that they wait for every other weight to
+// be released. It returns false if the context is cancelled first.
+func (s *weightedSemaphore) acquire(ctx context.Context, weight int64) bool {
+	weight = min(weight, s.size)
+
+	for {
+		s.mu.Lock()
+		if s.current+weight <= s.size {
+			s.current += weight
+			s.mu.Unlock()
+
+			return true
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-s.released:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// release subtracts a given weight from the total and wakes up the
+// goroutine waiting in acquire, if any.
+func (s *weightedSemaphore) release(weight int64) {
+	s.mu.Lock()
+	s.current -= min(weight, s.size)
+	s.mu.Unlock()
+
+	select {
+	case s.released <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,242 @@
+package gloop_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithParallelizeWeight(t *testing.T) {
+	options := gloop.ParallelizeOptions{}
+	gloop.WithParallelizeWeight(10, func(s string) int64 {
+		return int64(len(s))
+	})(&options)
+
+	require.EqualValues(t, 10, options.MaxWeight)
+	require.NotNil(t, options.Weight)
+	require.EqualValues(t, 4, options.Weight.(func(string) int64)("Fizz"))
+
+	gloop.WithParallelizeWeight[string](5, nil)(&options)
+
+	require.EqualValues(t, 5, options.MaxWeight)
+	require.Nil(t, options.Weight)
+}
+
+func TestParallelizeWeight(t *testing.T) {
+	values := []int64{3, 1, 2, 4, 2, 1, 3, 5}
+
+	var (
+		inFlightWeight    atomic.Int64
+		maxInFlightWeight atomic.Int64
+		called            atomic.Int64
+	)
+
+	done := make(chan struct{}, 1)
+	go func() {
+		gloop.Parallelize(gloop.Slice(values), func(v int64) {
+			called.Add(1)
+
+			n := inFlightWeight.Add(v)
+			defer inFlightWeight.Add(-v)
+
+			for {
+				m := maxInFlightWeight.Load()
+				if n <= m || maxInFlightWeight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond * 5)
+		}, gloop.WithParallelizeWeight(5, func(v int64) int64 {
+			return v
+		}))
+		done <- struct{}{}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, len(values), called.Load())
+	require.LessOrEqual(t, maxInFlightWeight.Load(), int64(5))
+}
+
+func TestParallelizeWeightAboveMaxRunsAlone(t *testing.T) {
+	values := []int64{1, 10, 1, 2}
+
+	var (
+		concurrentCallers atomic.Int64
+		heavyRanAlone     atomic.Bool
+	)
+
+	done := make(chan struct{}, 1)
+	go func() {
+		gloop.Parallelize(gloop.Slice(values), func(v int64) {
+			n := concurrentCallers.Add(1)
+			defer concurrentCallers.Add(-1)
+
+			time.Sleep(time.Millisecond * 5)
+
+			if v == 10 {
+				heavyRanAlone.Store(n == 1 && concurrentCallers.Load() == 1)
+			}
+		}, gloop.WithParallelizeWeight(4, func(v int64) int64 {
+			return v
+		}))
+		done <- struct{}{}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.True(t, heavyRanAlone.Load())
+}
+
+func TestParallelizeWeightDefault(t *testing.T) {
+	var (
+		concurrentCallers    atomic.Int64
+		maxConcurrentCallers atomic.Int64
+	)
+
+	done := make(chan struct{}, 1)
+	go func() {
+		gloop.Parallelize(gloop.Interval(0, 10, 1), func(_ int) {
+			n := concurrentCallers.Add(1)
+			defer concurrentCallers.Add(-1)
+
+			for {
+				m := maxConcurrentCallers.Load()
+				if n <= m || maxConcurrentCallers.CompareAndSwap(m, n) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond * 5)
+		}, gloop.WithParallelizeWeight[int](2, nil))
+		done <- struct{}{}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 2, maxConcurrentCallers.Load())
+}
+
+func TestParallelizeErrWeightCancelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	values := []int64{2, 2, 2}
+
+	var called atomic.Int64
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Slice(values), func(_ context.Context, _ int64) error {
+			called.Add(1)
+			cancel()
+			time.Sleep(time.Millisecond * 10)
+
+			return nil
+		},
+			gloop.WithParallelizeContext(ctx),
+			gloop.WithParallelizeMaxThreads(2),
+			gloop.WithParallelizeWeight(3, func(v int64) int64 {
+				return v
+			}),
+		)
+	}()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 1, called.Load())
+}
+
+func TestParallelizeErrWeightRateLimitCancelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	clock := newFakeClock(time.Now())
+	values := []int64{1, 1, 1}
+
+	var called atomic.Int64
+
+	done := make(chan error, 1)
+	go func() {
+		done <- gloop.ParallelizeErr(gloop.Slice(values), func(_ context.Context, _ int64) error {
+			called.Add(1)
+
+			return nil
+		},
+			gloop.WithParallelizeContext(ctx),
+			gloop.WithParallelizeRateLimit(1, 1),
+			gloop.WithParallelizeClock(clock),
+			gloop.WithParallelizeWeight(1, func(v int64) int64 {
+				return v
+			}),
+		)
+	}()
+
+	clock.WaitForAfter(t)
+	cancel()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second * 10):
+		t.Fatal("done signal took too long")
+	}
+
+	require.EqualValues(t, 1, called.Load())
+}
+
+func TestParallelizeWeightTypeMismatchPanics(t *testing.T) {
+	seq := gloop.Map(map[string]int{"Fizz": 4})
+
+	require.PanicsWithValue(t, "weight function must take the sequence's value type", func() {
+		gloop.Parallelize2(seq, func(_ string, _ int) {}, gloop.WithParallelizeWeight(2, func(key string) int64 {
+			return int64(len(key))
+		}))
+	})
+
+	require.PanicsWithValue(t, "weight function must take the sequence's value type", func() {
+		gloop.ParallelTransform(gloop.Slice([]int{4}), func(v int) int {
+			return v
+		}, gloop.WithParallelizeWeight(2, func(s string) int64 {
+			return int64(len(s))
+		}))
+	})
+}
+
+func TestParallelizeWeightInvalidPanics(t *testing.T) {
+	f := func(_ context.Context, _ int64) error {
+		return nil
+	}
+
+	require.Panics(t, func() {
+		_ = gloop.ParallelizeErr(gloop.Slice([]int64{1}), f, gloop.WithParallelizeWeight[int64](-1, nil))
+	})
+
+	require.Panics(t, func() {
+		_ = gloop.ParallelizeErr(gloop.Slice([]int64{-1}), f, gloop.WithParallelizeWeight(1, func(v int64) int64 {
+			return v
+		}))
+	})
+}
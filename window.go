@@ -4,68 +4,309 @@ import (
 	"iter"
 )
 
+// WindowOptions defines configurable options for [Window].
+type WindowOptions[V any] struct {
+	// Step is the number of values each window advances by. A step
+	// equal to the window size produces tumbling windows, and a step
+	// greater than the window size skips values between windows. The
+	// step must be positive.
+	Step int
+	// Partial indicates whether trailing windows with fewer values
+	// than the window size are yielded at the end of the sequence.
+	Partial bool
+	// Expanding indicates whether leading windows with fewer values
+	// than the window size are yielded at the start of the sequence.
+	Expanding bool
+	// Padded indicates whether partial windows will be padded to the
+	// window size. Leading windows are padded at the start and
+	// trailing windows are padded at the end.
+	Padded bool
+	// PadValue is the value partial windows are padded with. This is
+	// not used if Padded is false.
+	PadValue *V
+}
+
+// WindowOptionFunc is the function signature of configuration helpers
+// for [Window].
+type WindowOptionFunc[V any] func(*WindowOptions[V])
+
+// WithWindowStep is a helper for configuring the number of values each
+// window advances by in [Window].
+func WithWindowStep[V any](step int) WindowOptionFunc[V] {
+	return func(o *WindowOptions[V]) {
+		o.Step = step
+	}
+}
+
+// WithWindowPartial is a helper for configuring [Window] to yield
+// trailing partial windows.
+func WithWindowPartial[V any](partial bool) WindowOptionFunc[V] {
+	return func(o *WindowOptions[V]) {
+		o.Partial = partial
+	}
+}
+
+// WithWindowExpanding is a helper for configuring [Window] to yield
+// leading partial windows.
+func WithWindowExpanding[V any](expanding bool) WindowOptionFunc[V] {
+	return func(o *WindowOptions[V]) {
+		o.Expanding = expanding
+	}
+}
+
+// WithWindowPadded is a helper for configuring [Window] to pad partial
+// windows.
+func WithWindowPadded[V any](padded bool) WindowOptionFunc[V] {
+	return func(o *WindowOptions[V]) {
+		o.Padded = padded
+	}
+}
+
+// WithWindowPadValue is a helper for configuring padded values for
+// partial windows in [Window].
+func WithWindowPadValue[V any](value V) WindowOptionFunc[V] {
+	return func(o *WindowOptions[V]) {
+		o.PadValue = &value
+	}
+}
+
 // Window allows looping over an [iter.Seq] sequence in sliding windows
-// of a given size.
-func Window[V any](seq iter.Seq[V], size int) iter.Seq[iter.Seq[V]] {
+// of a given size. The window size and step must be positive. Values
+// are pulled from the sequence lazily and at most one window of values
+// is buffered at a time, so the sequence may be infinite. Each window
+// is only valid until the next window is requested.
+func Window[V any](
+	seq iter.Seq[V],
+	size int,
+	opts ...WindowOptionFunc[V],
+) iter.Seq[iter.Seq[V]] {
 	if size <= 0 {
 		panic("size must be positive")
 	}
 
-	l := ToList(seq)
+	options := WindowOptions[V]{
+		Step:      1,
+		Partial:   false,
+		Expanding: false,
+		Padded:    false,
+		PadValue:  nil,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.Step <= 0 {
+		panic("step must be positive")
+	}
+
+	var padValue V
+	if options.PadValue != nil {
+		padValue = *options.PadValue
+	}
 
 	return func(yield func(iter.Seq[V]) bool) {
-		firstElem := l.Front()
+		next, stop := iter.Pull(seq)
+		defer stop()
 
-		for range l.Len() - size + 1 {
-			if !yield(func(yield func(V) bool) {
-				elem := firstElem
-				for range size {
-					if !yield(elem.Value.(V)) {
-						return
-					}
+		buffer := newRing[V](size)
+		pulled := 0
+		exhausted := false
 
-					elem = elem.Next()
+		// start is the index of the first value in the current window.
+		// Leading partial windows start at negative indices, aligned
+		// so that the first full window starts at index zero.
+		start := 0
+		if options.Expanding {
+			start = -((size - 1) / options.Step) * options.Step
+		}
+
+		for {
+			for !exhausted && pulled < start+size {
+				value, ok := next()
+				if !ok {
+					exhausted = true
+
+					break
 				}
-			}) {
+
+				if pulled >= start {
+					buffer.Push(value)
+				}
+
+				pulled++
+			}
+
+			low := max(start, 0)
+			high := min(start+size, pulled)
+
+			if high <= low {
 				return
 			}
 
-			firstElem = firstElem.Next()
-		}
-	}
-}
+			if start+size > pulled && !options.Partial {
+				return
+			}
 
-// Window2 allows looping over an [iter.Seq2] sequence in sliding
-// windows of a given size.
-func Window2[K, V any](seq iter.Seq2[K, V], size int) iter.Seq[iter.Seq2[K, V]] {
-	if size <= 0 {
-		panic("size must be positive")
-	}
+			offset := low - (pulled - buffer.Len())
+			count := high - low
+			padStart := 0
+			padEnd := 0
 
-	listKeys, listValues := ToList2(seq)
+			if options.Padded {
+				padStart = low - start
+				padEnd = start + size - high
+			}
 
-	return func(yield func(iter.Seq2[K, V]) bool) {
-		firstKeyElem := listKeys.Front()
-		firstValueElem := listValues.Front()
+			window := func(yield func(V) bool) {
+				for range padStart {
+					if !yield(padValue) {
+						return
+					}
+				}
 
-		for range listKeys.Len() - size + 1 {
-			if !yield(func(yield func(K, V) bool) {
-				keyElem := firstKeyElem
-				valueElem := firstValueElem
-				for range size {
-					if !yield(keyElem.Value.(K), valueElem.Value.(V)) {
+				for i := range count {
+					if !yield(buffer.At(offset + i)) {
 						return
 					}
+				}
 
-					keyElem = keyElem.Next()
-					valueElem = valueElem.Next()
+				for range padEnd {
+					if !yield(padValue) {
+						return
+					}
 				}
-			}) {
+			}
+
+			if !yield(window) {
 				return
 			}
 
-			firstKeyElem = firstKeyElem.Next()
-			firstValueElem = firstValueElem.Next()
+			start += options.Step
+
+			for buffer.Len() > 0 && pulled-buffer.Len() < start {
+				buffer.Pop()
+			}
 		}
 	}
 }
+
+// Window2Options defines configurable options for [Window2].
+type Window2Options[K, V any] struct {
+	// Step is the number of key value pairs each window advances by. A
+	// step equal to the window size produces tumbling windows, and a
+	// step greater than the window size skips pairs between windows.
+	// The step must be positive.
+	Step int
+	// Partial indicates whether trailing windows with fewer pairs than
+	// the window size are yielded at the end of the sequence.
+	Partial bool
+	// Expanding indicates whether leading windows with fewer pairs than
+	// the window size are yielded at the start of the sequence.
+	Expanding bool
+	// Padded indicates whether partial windows will be padded to the
+	// window size. Leading windows are padded at the start and
+	// trailing windows are padded at the end.
+	Padded bool
+	// PadKey is the key partial windows are padded with. This is not
+	// used if Padded is false.
+	PadKey *K
+	// PadValue is the value partial windows are padded with. This is
+	// not used if Padded is false.
+	PadValue *V
+}
+
+// Window2OptionFunc is the function signature of configuration helpers
+// for [Window2].
+type Window2OptionFunc[K, V any] func(*Window2Options[K, V])
+
+// WithWindow2Step is a helper for configuring the number of key value
+// pairs each window advances by in [Window2].
+func WithWindow2Step[K, V any](step int) Window2OptionFunc[K, V] {
+	return func(o *Window2Options[K, V]) {
+		o.Step = step
+	}
+}
+
+// WithWindow2Partial is a helper for configuring [Window2] to yield
+// trailing partial windows.
+func WithWindow2Partial[K, V any](partial bool) Window2OptionFunc[K, V] {
+	return func(o *Window2Options[K, V]) {
+		o.Partial = partial
+	}
+}
+
+// WithWindow2Expanding is a helper for configuring [Window2] to yield
+// leading partial windows.
+func WithWindow2Expanding[K, V any](expanding bool) Window2OptionFunc[K, V] {
+	return func(o *Window2Options[K, V]) {
+		o.Expanding = expanding
+	}
+}
+
+// WithWindow2Padded is a helper for configuring [Window2] to pad
+// partial windows.
+func WithWindow2Padded[K, V any](padded bool) Window2OptionFunc[K, V] {
+	return func(o *Window2Options[K, V]) {
+		o.Padded = padded
+	}
+}
+
+// WithWindow2PadKey is a helper for configuring padded keys for
+// partial windows in [Window2].
+func WithWindow2PadKey[K, V any](key K) Window2OptionFunc[K, V] {
+	return func(o *Window2Options[K, V]) {
+		o.PadKey = &key
+	}
+}
+
+// WithWindow2PadValue is a helper for configuring padded values for
+// partial windows in [Window2].
+func WithWindow2PadValue[K, V any](value V) Window2OptionFunc[K, V] {
+	return func(o *Window2Options[K, V]) {
+		o.PadValue = &value
+	}
+}
+
+// Window2 allows looping over an [iter.Seq2] sequence in sliding
+// windows of a given size. The window size and step must be positive.
+// Values are pulled from the sequence lazily and at most one window of
+// values is buffered at a time, so the sequence may be infinite. Each
+// window is only valid until the next window is requested.
+func Window2[K, V any](
+	seq iter.Seq2[K, V],
+	size int,
+	opts ...Window2OptionFunc[K, V],
+) iter.Seq[iter.Seq2[K, V]] {
+	options := Window2Options[K, V]{
+		Step:      1,
+		Partial:   false,
+		Expanding: false,
+		Padded:    false,
+		PadKey:    nil,
+		PadValue:  nil,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	padPair := KeyValuePair[K, V]{}
+	if options.PadKey != nil {
+		padPair.Key = *options.PadKey
+	}
+
+	if options.PadValue != nil {
+		padPair.Value = *options.PadValue
+	}
+
+	return Transform(Window(
+		KeyValue2(seq),
+		size,
+		WithWindowStep[KeyValuePair[K, V]](options.Step),
+		WithWindowPartial[KeyValuePair[K, V]](options.Partial),
+		WithWindowExpanding[KeyValuePair[K, V]](options.Expanding),
+		WithWindowPadded[KeyValuePair[K, V]](options.Padded),
+		WithWindowPadValue(padPair),
+	), KeyValue[K, V])
+}
@@ -180,3 +180,590 @@ func TestWindow2NegativeSizePanics(t *testing.T) {
 		}
 	})
 }
+
+func TestWindowInfiniteSequence(t *testing.T) {
+	naturals := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	wantWindows := [][]int{
+		{0, 1, 2},
+		{1, 2, 3},
+		{2, 3, 4},
+	}
+	i := 0
+
+	for seq := range gloop.Window(naturals, 3) {
+		if i == len(wantWindows) {
+			break
+		}
+
+		window := gloop.ToSlice(seq)
+		require.Equal(t, wantWindows[i], window)
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestWindowShorterThanSize(t *testing.T) {
+	for range gloop.Window(gloop.Slice([]int{3, 1}), 3) {
+		t.Fatal("expected no iteration")
+	}
+}
+
+func TestWindowSizeLargerThanSequence(t *testing.T) {
+	values := []int{3, 1, 4}
+	wantWindows := [][]int{
+		{3, 1, 4},
+		{1, 4},
+		{4},
+	}
+
+	windows := gloop.ToSlice(gloop.Transform(
+		gloop.Window(gloop.Slice(values), 1e8, gloop.WithWindowPartial[int](true)),
+		gloop.ToSlice[int],
+	))
+	require.Equal(t, wantWindows, windows)
+
+	i := 0
+
+	for seq := range gloop.Window2(gloop.Enumerate(gloop.Slice(values)), 1e8, gloop.WithWindow2Partial[int, int](true)) {
+		_, window := gloop.ToSlice2(seq)
+		require.Equal(t, wantWindows[i], window)
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestWindowLazy(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5, 9, 2, 6, 5}
+	pulled := 0
+	seq := func(yield func(int) bool) {
+		for _, value := range values {
+			pulled++
+
+			if !yield(value) {
+				return
+			}
+		}
+	}
+
+	for range gloop.Window(seq, 3) {
+		require.Equal(t, 3, pulled)
+
+		break
+	}
+
+	require.Equal(t, 3, pulled)
+}
+
+func TestWithWindowStep(t *testing.T) {
+	step := 3
+	options := gloop.WindowOptions[int]{}
+	gloop.WithWindowStep[int](step)(&options)
+	require.Equal(t, step, options.Step)
+}
+
+func TestWithWindowPartialTrue(t *testing.T) {
+	options := gloop.WindowOptions[int]{
+		Partial: false,
+	}
+	gloop.WithWindowPartial[int](true)(&options)
+	require.True(t, options.Partial)
+}
+
+func TestWithWindowPartialFalse(t *testing.T) {
+	options := gloop.WindowOptions[int]{
+		Partial: true,
+	}
+	gloop.WithWindowPartial[int](false)(&options)
+	require.False(t, options.Partial)
+}
+
+func TestWithWindowExpandingTrue(t *testing.T) {
+	options := gloop.WindowOptions[int]{
+		Expanding: false,
+	}
+	gloop.WithWindowExpanding[int](true)(&options)
+	require.True(t, options.Expanding)
+}
+
+func TestWithWindowExpandingFalse(t *testing.T) {
+	options := gloop.WindowOptions[int]{
+		Expanding: true,
+	}
+	gloop.WithWindowExpanding[int](false)(&options)
+	require.False(t, options.Expanding)
+}
+
+func TestWithWindowPaddedTrue(t *testing.T) {
+	options := gloop.WindowOptions[int]{
+		Padded: false,
+	}
+	gloop.WithWindowPadded[int](true)(&options)
+	require.True(t, options.Padded)
+}
+
+func TestWithWindowPaddedFalse(t *testing.T) {
+	options := gloop.WindowOptions[int]{
+		Padded: true,
+	}
+	gloop.WithWindowPadded[int](false)(&options)
+	require.False(t, options.Padded)
+}
+
+func TestWithWindowPadValue(t *testing.T) {
+	value := 42
+	options := gloop.WindowOptions[int]{}
+	gloop.WithWindowPadValue(value)(&options)
+
+	require.NotNil(t, options.PadValue)
+	require.Equal(t, value, *options.PadValue)
+}
+
+func TestWindowStep(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5, 9, 2, 6, 5}
+	wantWindows := [][]int{
+		{3, 1, 4},
+		{4, 1, 5},
+		{5, 9, 2},
+		{2, 6, 5},
+	}
+	i := 0
+
+	for seq := range gloop.Window(gloop.Slice(values), 3, gloop.WithWindowStep[int](2)) {
+		window := gloop.ToSlice(seq)
+		require.Equal(t, wantWindows[i], window)
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestWindowStepTumbling(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5, 9, 2, 6, 5, 3}
+	wantWindows := [][]int{
+		{3, 1, 4},
+		{1, 5, 9},
+		{2, 6, 5},
+	}
+	i := 0
+
+	for seq := range gloop.Window(gloop.Slice(values), 3, gloop.WithWindowStep[int](3)) {
+		window := gloop.ToSlice(seq)
+		require.Equal(t, wantWindows[i], window)
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestWindowStepGreaterThanSize(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5, 9, 2, 6, 5}
+	wantWindows := [][]int{
+		{3, 1},
+		{1, 5},
+		{2, 6},
+	}
+	i := 0
+
+	for seq := range gloop.Window(gloop.Slice(values), 2, gloop.WithWindowStep[int](3)) {
+		window := gloop.ToSlice(seq)
+		require.Equal(t, wantWindows[i], window)
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestWindowPartial(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5, 9, 2}
+	wantWindows := [][]int{
+		{3, 1, 4},
+		{1, 5, 9},
+		{2},
+	}
+	i := 0
+
+	for seq := range gloop.Window(
+		gloop.Slice(values),
+		3,
+		gloop.WithWindowStep[int](3),
+		gloop.WithWindowPartial[int](true),
+	) {
+		window := gloop.ToSlice(seq)
+		require.Equal(t, wantWindows[i], window)
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestWindowPartialSliding(t *testing.T) {
+	values := []int{3, 1, 4, 1}
+	wantWindows := [][]int{
+		{3, 1, 4},
+		{1, 4, 1},
+		{4, 1},
+		{1},
+	}
+	i := 0
+
+	for seq := range gloop.Window(gloop.Slice(values), 3, gloop.WithWindowPartial[int](true)) {
+		window := gloop.ToSlice(seq)
+		require.Equal(t, wantWindows[i], window)
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestWindowPaddedZeroValue(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5}
+	wantWindows := [][]int{
+		{3, 1, 4},
+		{1, 5, 0},
+	}
+	i := 0
+
+	for seq := range gloop.Window(
+		gloop.Slice(values),
+		3,
+		gloop.WithWindowStep[int](3),
+		gloop.WithWindowPartial[int](true),
+		gloop.WithWindowPadded[int](true),
+	) {
+		window := gloop.ToSlice(seq)
+		require.Equal(t, wantWindows[i], window)
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestWindowPadValue(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5}
+	wantWindows := [][]int{
+		{3, 1, 4},
+		{1, 5, 42},
+	}
+	i := 0
+
+	for seq := range gloop.Window(
+		gloop.Slice(values),
+		3,
+		gloop.WithWindowStep[int](3),
+		gloop.WithWindowPartial[int](true),
+		gloop.WithWindowPadded[int](true),
+		gloop.WithWindowPadValue(42),
+	) {
+		window := gloop.ToSlice(seq)
+		require.Equal(t, wantWindows[i], window)
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestWindowExpanding(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5}
+	wantWindows := [][]int{
+		{3},
+		{3, 1},
+		{3, 1, 4},
+		{1, 4, 1},
+		{4, 1, 5},
+	}
+	i := 0
+
+	for seq := range gloop.Window(gloop.Slice(values), 3, gloop.WithWindowExpanding[int](true)) {
+		window := gloop.ToSlice(seq)
+		require.Equal(t, wantWindows[i], window)
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestWindowExpandingStep(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5, 9}
+	wantWindows := [][]int{
+		{3},
+		{3, 1, 4},
+		{4, 1, 5},
+	}
+	i := 0
+
+	for seq := range gloop.Window(
+		gloop.Slice(values),
+		3,
+		gloop.WithWindowStep[int](2),
+		gloop.WithWindowExpanding[int](true),
+	) {
+		window := gloop.ToSlice(seq)
+		require.Equal(t, wantWindows[i], window)
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestWindowExpandingPartialPadded(t *testing.T) {
+	values := []int{3, 1}
+	wantWindows := [][]int{
+		{-1, -1, 3},
+		{-1, 3, 1},
+		{3, 1, -1},
+		{1, -1, -1},
+	}
+	i := 0
+
+	for seq := range gloop.Window(
+		gloop.Slice(values),
+		3,
+		gloop.WithWindowExpanding[int](true),
+		gloop.WithWindowPartial[int](true),
+		gloop.WithWindowPadded[int](true),
+		gloop.WithWindowPadValue(-1),
+	) {
+		window := gloop.ToSlice(seq)
+		require.Equal(t, wantWindows[i], window)
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestWindowPaddedBreak(t *testing.T) {
+	values := []int{3, 1}
+
+	for seq := range gloop.Window(
+		gloop.Slice(values),
+		3,
+		gloop.WithWindowExpanding[int](true),
+		gloop.WithWindowPartial[int](true),
+		gloop.WithWindowPadded[int](true),
+	) {
+		for value := range seq {
+			require.Equal(t, 0, value)
+
+			break
+		}
+
+		break
+	}
+
+	for seq := range gloop.Window(
+		gloop.Slice(values),
+		3,
+		gloop.WithWindowStep[int](3),
+		gloop.WithWindowPartial[int](true),
+		gloop.WithWindowPadded[int](true),
+	) {
+		i := 0
+		for value := range seq {
+			if i == 2 {
+				require.Equal(t, 0, value)
+
+				break
+			}
+
+			i++
+		}
+
+		break
+	}
+}
+
+func TestWindowZeroStepPanics(t *testing.T) {
+	require.Panics(t, func() {
+		for range gloop.Window(gloop.Slice([]int{3, 1, 4}), 2, gloop.WithWindowStep[int](0)) {
+			t.Fatal("expected no iteration")
+		}
+	})
+}
+
+func TestWithWindow2Step(t *testing.T) {
+	step := 3
+	options := gloop.Window2Options[string, int]{}
+	gloop.WithWindow2Step[string, int](step)(&options)
+	require.Equal(t, step, options.Step)
+}
+
+func TestWithWindow2PartialTrue(t *testing.T) {
+	options := gloop.Window2Options[string, int]{
+		Partial: false,
+	}
+	gloop.WithWindow2Partial[string, int](true)(&options)
+	require.True(t, options.Partial)
+}
+
+func TestWithWindow2PartialFalse(t *testing.T) {
+	options := gloop.Window2Options[string, int]{
+		Partial: true,
+	}
+	gloop.WithWindow2Partial[string, int](false)(&options)
+	require.False(t, options.Partial)
+}
+
+func TestWithWindow2ExpandingTrue(t *testing.T) {
+	options := gloop.Window2Options[string, int]{
+		Expanding: false,
+	}
+	gloop.WithWindow2Expanding[string, int](true)(&options)
+	require.True(t, options.Expanding)
+}
+
+func TestWithWindow2ExpandingFalse(t *testing.T) {
+	options := gloop.Window2Options[string, int]{
+		Expanding: true,
+	}
+	gloop.WithWindow2Expanding[string, int](false)(&options)
+	require.False(t, options.Expanding)
+}
+
+func TestWithWindow2PaddedTrue(t *testing.T) {
+	options := gloop.Window2Options[string, int]{
+		Padded: false,
+	}
+	gloop.WithWindow2Padded[string, int](true)(&options)
+	require.True(t, options.Padded)
+}
+
+func TestWithWindow2PaddedFalse(t *testing.T) {
+	options := gloop.Window2Options[string, int]{
+		Padded: true,
+	}
+	gloop.WithWindow2Padded[string, int](false)(&options)
+	require.False(t, options.Padded)
+}
+
+func TestWithWindow2PadKey(t *testing.T) {
+	key := "FizzBuzz"
+	options := gloop.Window2Options[string, int]{}
+	gloop.WithWindow2PadKey[string, int](key)(&options)
+
+	require.NotNil(t, options.PadKey)
+	require.Equal(t, key, *options.PadKey)
+}
+
+func TestWithWindow2PadValue(t *testing.T) {
+	value := 42
+	options := gloop.Window2Options[string, int]{}
+	gloop.WithWindow2PadValue[string](value)(&options)
+
+	require.NotNil(t, options.PadValue)
+	require.Equal(t, value, *options.PadValue)
+}
+
+func TestWindow2Step(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5, 9, 2}
+	wantKeys := [][]int{
+		{0, 1, 2},
+		{3, 4, 5},
+	}
+	wantWindows := [][]int{
+		{3, 1, 4},
+		{1, 5, 9},
+	}
+	i := 0
+
+	for seq := range gloop.Window2(
+		gloop.Enumerate(gloop.Slice(values)),
+		3,
+		gloop.WithWindow2Step[int, int](3),
+	) {
+		keys, window := gloop.ToSlice2(seq)
+		require.Equal(t, wantKeys[i], keys)
+		require.Equal(t, wantWindows[i], window)
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestWindow2PaddedZeroKeyValue(t *testing.T) {
+	values := []int{3, 1, 4, 1}
+	wantKeys := [][]int{
+		{0, 0, 0},
+		{0, 0, 1},
+		{0, 1, 2},
+		{1, 2, 3},
+		{2, 3, 0},
+		{3, 0, 0},
+	}
+	wantWindows := [][]int{
+		{0, 0, 3},
+		{0, 3, 1},
+		{3, 1, 4},
+		{1, 4, 1},
+		{4, 1, 0},
+		{1, 0, 0},
+	}
+	i := 0
+
+	for seq := range gloop.Window2(
+		gloop.Enumerate(gloop.Slice(values)),
+		3,
+		gloop.WithWindow2Expanding[int, int](true),
+		gloop.WithWindow2Partial[int, int](true),
+		gloop.WithWindow2Padded[int, int](true),
+	) {
+		keys, window := gloop.ToSlice2(seq)
+		require.Equal(t, wantKeys[i], keys)
+		require.Equal(t, wantWindows[i], window)
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
+
+func TestWindow2PadKeyValue(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5}
+	wantKeys := [][]int{
+		{0, 1, 2},
+		{3, 4, -1},
+	}
+	wantWindows := [][]int{
+		{3, 1, 4},
+		{1, 5, 42},
+	}
+	i := 0
+
+	for seq := range gloop.Window2(
+		gloop.Enumerate(gloop.Slice(values)),
+		3,
+		gloop.WithWindow2Step[int, int](3),
+		gloop.WithWindow2Partial[int, int](true),
+		gloop.WithWindow2Padded[int, int](true),
+		gloop.WithWindow2PadKey[int, int](-1),
+		gloop.WithWindow2PadValue[int](42),
+	) {
+		keys, window := gloop.ToSlice2(seq)
+		require.Equal(t, wantKeys[i], keys)
+		require.Equal(t, wantWindows[i], window)
+
+		i++
+	}
+
+	require.Equal(t, len(wantWindows), i)
+}
@@ -0,0 +1,89 @@
+package gloop
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+)
+
+// XMLElements allows looping over the elements of an XML document
+// read from an [io.Reader] that match a given path, each decoded into
+// a value of a given type using [encoding/xml]. The path is a list of
+// slash separated element names, matched against the innermost
+// elements, so "item" matches every item element and "channel/item"
+// only matches item elements directly inside channel elements. A path
+// starting with a slash is matched from the root element. Names are
+// matched without namespaces. Elements are decoded one at a time, so
+// the whole document is never held in memory. If reading, parsing or
+// decoding fails, the error is yielded along with a zero value and
+// looping ends.
+func XMLElements[T any](r io.Reader, path string) iter.Seq2[T, error] {
+	absolute := strings.HasPrefix(path, "/")
+	names := strings.Split(strings.Trim(path, "/"), "/")
+
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		decoder := xml.NewDecoder(r)
+		stack := make([]string, 0)
+
+		for {
+			token, err := decoder.Token()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil {
+				yield(zero, fmt.Errorf("failed to read XML token: %w", err))
+
+				return
+			}
+
+			switch elem := token.(type) {
+			case xml.StartElement:
+				stack = append(stack, elem.Name.Local)
+
+				if !matchXMLPath(stack, names, absolute) {
+					continue
+				}
+
+				var value T
+
+				err := decoder.DecodeElement(&value, &elem)
+				if err != nil {
+					yield(zero, fmt.Errorf("failed to decode XML element: %w", err))
+
+					return
+				}
+
+				stack = stack[:len(stack)-1]
+
+				if !yield(value, nil) {
+					return
+				}
+			case xml.EndElement:
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+}
+
+// matchXMLPath returns whether or not a stack of element names matches
+// a path of element names.
+func matchXMLPath(stack []string, names []string, absolute bool) bool {
+	if len(stack) < len(names) || (absolute && len(stack) != len(names)) {
+		return false
+	}
+
+	offset := len(stack) - len(names)
+	for i, name := range names {
+		if stack[offset+i] != name {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,146 @@
+package gloop_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/alvii147/gloop"
+	"github.com/stretchr/testify/require"
+)
+
+type xmlItem struct {
+	Title string `xml:"title"`
+	Legs  int    `xml:"legs,attr"`
+}
+
+const xmlFeed = `<?xml version="1.0"?>
+<rss xmlns:a="https://example.com/a">
+	<channel>
+		<title>Animals</title>
+		<item legs="4"><title>CAT</title></item>
+		<item legs="2"><title>CHICKEN</title></item>
+		<a:item legs="4"><title>MOUSE</title></a:item>
+	</channel>
+	<archive>
+		<item legs="4"><title>BEAR</title></item>
+	</archive>
+</rss>`
+
+func TestXMLElementsName(t *testing.T) {
+	r := iotest.OneByteReader(strings.NewReader(xmlFeed))
+	wantItems := []xmlItem{
+		{Title: "CAT", Legs: 4},
+		{Title: "CHICKEN", Legs: 2},
+		{Title: "MOUSE", Legs: 4},
+		{Title: "BEAR", Legs: 4},
+	}
+	i := 0
+
+	for item, err := range gloop.XMLElements[xmlItem](r, "item") {
+		require.NoError(t, err)
+		require.Equal(t, wantItems[i], item)
+
+		i++
+	}
+
+	require.Equal(t, len(wantItems), i)
+}
+
+func TestXMLElementsRelativePath(t *testing.T) {
+	r := strings.NewReader(xmlFeed)
+	wantItems := []xmlItem{
+		{Title: "CAT", Legs: 4},
+		{Title: "CHICKEN", Legs: 2},
+		{Title: "MOUSE", Legs: 4},
+	}
+	i := 0
+
+	for item, err := range gloop.XMLElements[xmlItem](r, "channel/item") {
+		require.NoError(t, err)
+		require.Equal(t, wantItems[i], item)
+
+		i++
+	}
+
+	require.Equal(t, len(wantItems), i)
+}
+
+func TestXMLElementsAbsolutePath(t *testing.T) {
+	r := strings.NewReader(xmlFeed)
+	wantTitles := []string{"Animals"}
+	i := 0
+
+	for title, err := range gloop.XMLElements[string](r, "/rss/channel/title") {
+		require.NoError(t, err)
+		require.Equal(t, wantTitles[i], title)
+
+		i++
+	}
+
+	require.Equal(t, len(wantTitles), i)
+}
+
+func TestXMLElementsNoMatch(t *testing.T) {
+	r := strings.NewReader(xmlFeed)
+
+	for range gloop.XMLElements[xmlItem](r, "/item") {
+		t.Fatal("expected no iteration")
+	}
+}
+
+func TestXMLElementsSyntaxError(t *testing.T) {
+	r := strings.NewReader(`<rss><item><title>CAT</title></item><item></rss>`)
+	i := 0
+
+	for item, err := range gloop.XMLElements[xmlItem](r, "item") {
+		if i == 0 {
+			require.NoError(t, err)
+			require.Equal(t, "CAT", item.Title)
+		} else {
+			require.Error(t, err)
+			require.Equal(t, xmlItem{}, item)
+		}
+
+		i++
+	}
+
+	require.Equal(t, 2, i)
+}
+
+func TestXMLElementsDecodeError(t *testing.T) {
+	r := strings.NewReader(`<rss><item legs="four"><title>CAT</title></item></rss>`)
+	i := 0
+
+	for item, err := range gloop.XMLElements[xmlItem](r, "item") {
+		require.Error(t, err)
+		require.Equal(t, xmlItem{}, item)
+
+		i++
+	}
+
+	require.Equal(t, 1, i)
+}
+
+func TestXMLElementsReadError(t *testing.T) {
+	r := io.MultiReader(strings.NewReader(`<rss><item>`), iotest.ErrReader(errReaderTest))
+	var gotErr error
+
+	for _, err := range gloop.XMLElements[xmlItem](r, "title") {
+		gotErr = err
+	}
+
+	require.ErrorIs(t, gotErr, errReaderTest)
+}
+
+func TestXMLElementsBreak(t *testing.T) {
+	r := strings.NewReader(xmlFeed)
+
+	for item, err := range gloop.XMLElements[xmlItem](r, "item") {
+		require.NoError(t, err)
+		require.Equal(t, "CAT", item.Title)
+
+		break
+	}
+}